@@ -11,13 +11,13 @@ import (
 )
 
 // PrintVerboseAlerts prints Alerts in verbose format.
-func PrintVerboseAlerts(linted []*core.File, wrap bool) bool {
+func PrintVerboseAlerts(linted []*core.File, config *core.Config) {
 	var errors, warnings, suggestions int
 	var e, w, s int
 	var symbol string
 
 	for _, f := range linted {
-		e, w, s = printVerboseAlert(f, wrap)
+		e, w, s = printVerboseAlert(f, config)
 		errors += e
 		warnings += w
 		suggestions += s
@@ -43,12 +43,10 @@ func PrintVerboseAlerts(linted []*core.File, wrap bool) bool {
 			pterm.Red(etotal), pterm.Yellow(wtotal),
 			pterm.Blue(stotal), n, pluralize("file", n))
 	}
-
-	return errors != 0
 }
 
 // printVerboseAlert includes an alert's line, column, level, and message.
-func printVerboseAlert(f *core.File, wrap bool) (int, int, int) {
+func printVerboseAlert(f *core.File, config *core.Config) (int, int, int) {
 	var loc, level string
 	var errors, warnings, notifications int
 
@@ -61,11 +59,13 @@ func printVerboseAlert(f *core.File, wrap bool) (int, int, int) {
 	table.SetCenterSeparator("")
 	table.SetColumnSeparator("")
 	table.SetRowSeparator("")
-	table.SetAutoWrapText(!wrap)
+	table.SetAutoWrapText(!config.Flags.Wrap)
 
 	fmt.Printf("\n %s", pterm.Underscore.Sprintf(f.Path))
 	for _, a := range alerts {
-		switch a.Severity {
+		// NOTE: Custom levels are colored and counted alongside their
+		// nearest built-in level.
+		switch config.LevelGroup(a.Severity) {
 		case "suggestion":
 			level = pterm.Blue(a.Severity)
 			notifications++
@@ -27,22 +27,26 @@ type CompiledRule struct {
 }
 
 var commandInfo = map[string]string{
-	"ls-config":  "Print the current configuration to stdout.",
-	"ls-metrics": "Print the given file's internal metrics to stdout.",
-	"sync":       "Download and install external configuration sources.",
-	"fix":        "Attempt to automatically fix the given alert.",
+	"ls-config":       "Print the current configuration to stdout.",
+	"ls-metrics":      "Print the given file's internal metrics to stdout.",
+	"ls-suppressions": "Print the in-text comments that disable rules in the given files.",
+	"sync":            "Download and install external configuration sources.",
+	"fix":             "Attempt to automatically fix the given alert.",
+	"schema":          "Print the JSON Schema for 'config' or 'rule' files.",
 }
 
 // Actions are the available CLI commands.
 var Actions = map[string]func(args []string, flags *core.CLIFlags) error{
-	"ls-config":  printConfig,
-	"ls-metrics": printMetrics,
-	"dc":         printConfig,
-	"tag":        runTag,
-	"compile":    compileRule,
-	"run":        runRule,
-	"sync":       sync,
-	"fix":        fix,
+	"ls-config":       printConfig,
+	"ls-metrics":      printMetrics,
+	"ls-suppressions": printSuppressions,
+	"dc":              printConfig,
+	"tag":             runTag,
+	"compile":         compileRule,
+	"run":             runRule,
+	"sync":            sync,
+	"fix":             fix,
+	"schema":          printSchema,
 }
 
 func fix(args []string, flags *core.CLIFlags) error {
@@ -109,11 +113,40 @@ func printConfig(_ []string, flags *core.CLIFlags) error {
 	cfg, err := core.ReadPipeline("ini", flags, false)
 	if err != nil {
 		return err
+	} else if flags.Trace {
+		return printJSON(cfg.Trace())
 	}
 	fmt.Println(cfg.String())
 	return nil
 }
 
+func printSchema(args []string, flags *core.CLIFlags) error {
+	if len(args) != 1 {
+		return core.NewE100("schema", errors.New("one argument expected"))
+	}
+
+	// The schemas list the levels defined by the current configuration, if
+	// there is one.
+	cfg, err := core.ReadPipeline("ini", flags, true)
+	if err != nil {
+		cfg, err = core.NewConfig(flags)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch args[0] {
+	case "config":
+		return printJSON(core.ConfigSchema(cfg.AlertLevels))
+	case "rule":
+		return printJSON(check.RuleSchema(cfg.AlertLevels))
+	default:
+		return core.NewE100(
+			"schema",
+			fmt.Errorf("unknown schema '%s'; must be 'config' or 'rule'", args[0]))
+	}
+}
+
 func printMetrics(args []string, _ *core.CLIFlags) error {
 	if len(args) != 1 {
 		return core.NewE100("ls-metrics", errors.New("one argument expected"))
@@ -134,6 +167,7 @@ func printMetrics(args []string, _ *core.CLIFlags) error {
 	if err != nil {
 		return err
 	}
+	defer linter.Manager.Close()
 
 	linted, err := linter.Lint([]string{args[0]}, "*")
 	if err != nil {
@@ -144,6 +178,40 @@ func printMetrics(args []string, _ *core.CLIFlags) error {
 	return printJSON(computed)
 }
 
+func printSuppressions(args []string, flags *core.CLIFlags) error {
+	if len(args) == 0 {
+		return core.NewE100("ls-suppressions", errors.New("at least one argument expected"))
+	}
+
+	// We need to run disabled rules to know whether a comment is needed.
+	flags.ReportUnused = true
+
+	cfg, err := core.ReadPipeline("ini", flags, false)
+	if err != nil {
+		return err
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+	defer linter.Manager.Close()
+
+	linted, err := doLint(args, linter, flags.Glob)
+	if err != nil {
+		return err
+	}
+
+	report := map[string][]*core.Suppression{}
+	for _, f := range linted {
+		if len(f.Suppressions) > 0 {
+			report[f.Path] = f.Suppressions
+		}
+	}
+
+	return printJSON(report)
+}
+
 func runTag(args []string, _ *core.CLIFlags) error {
 	if len(args) != 3 {
 		return core.NewE100("tag", errors.New("three arguments expected"))
@@ -177,6 +245,7 @@ func compileRule(args []string, _ *core.CLIFlags) error {
 	if err != nil {
 		return err
 	}
+	defer mgr.Close()
 
 	err = mgr.AddRuleFromFile(name, path)
 	if err != nil {
@@ -205,6 +274,7 @@ func runRule(args []string, _ *core.CLIFlags) error {
 	if err != nil {
 		return err
 	}
+	defer linter.Manager.Close()
 
 	err = linter.Manager.AddRuleFromFile("Test.Rule", args[0])
 	if err != nil {
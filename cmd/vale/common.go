@@ -1,24 +1,63 @@
 package main
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/errata-ai/vale/v2/internal/core"
 )
 
 // PrintAlerts prints the given alerts in the user-specified format.
+//
+// It returns `true` if any of the alerts are at or above the `--fail-on`
+// level.
 func PrintAlerts(linted []*core.File, config *core.Config) (bool, error) {
+	var err error
+
 	if config.Flags.Sorted {
 		sort.Sort(core.ByName(linted))
 	}
+
 	switch config.Flags.Output {
 	case "JSON":
-		return PrintJSONAlerts(linted), nil
+		PrintJSONAlerts(linted)
 	case "line":
-		return PrintLineAlerts(linted, config.Flags.Relative), nil
+		PrintLineAlerts(linted, config.Flags.Relative)
 	case "CLI":
-		return PrintVerboseAlerts(linted, config.Flags.Wrap), nil
+		PrintVerboseAlerts(linted, config)
 	default:
-		return PrintCustomAlerts(linted, config)
+		err = PrintCustomAlerts(linted, config)
+	}
+
+	return hasFailures(linted, config), err
+}
+
+// failLevel returns the level given by `--fail-on`, which defaults to
+// "error".
+func failLevel(config *core.Config) (string, error) {
+	level := config.Flags.FailOn
+	if level == "" {
+		return "error", nil
+	} else if !core.StringInSlice(level, config.AlertLevels) {
+		return level, fmt.Errorf(
+			"unknown level '%s'; must be one of %v", level, config.AlertLevels)
 	}
+	return level, nil
+}
+
+// hasFailures determines if any of the given alerts are at or above the
+// `--fail-on` level.
+func hasFailures(linted []*core.File, config *core.Config) bool {
+	level, _ := failLevel(config)
+
+	threshold := config.LevelToInt[level]
+	for _, f := range linted {
+		for _, a := range f.Alerts {
+			if config.LevelToInt[a.Severity] >= threshold {
+				return true
+			}
+		}
+	}
+
+	return false
 }
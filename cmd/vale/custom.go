@@ -22,9 +22,7 @@ type Data struct {
 }
 
 // PrintCustomAlerts formats the given alerts using a user-defined template.
-func PrintCustomAlerts(linted []*core.File, cfg *core.Config) (bool, error) {
-	var alertCount int
-
+func PrintCustomAlerts(linted []*core.File, cfg *core.Config) error {
 	path := cfg.Flags.Output
 	if !core.FileExists(path) {
 		path = core.FindAsset(cfg, path)
@@ -32,13 +30,13 @@ func PrintCustomAlerts(linted []*core.File, cfg *core.Config) (bool, error) {
 
 	b, err := os.ReadFile(path)
 	if err != nil {
-		return false, core.NewE100("template", err)
+		return core.NewE100("template", err)
 	}
 	text := string(b)
 
 	t, err := template.New(filepath.Base(path)).Funcs(sprig.TxtFuncMap()).Funcs(funcs).Parse(text)
 	if err != nil {
-		return false, core.NewE100("template", err)
+		return core.NewE100("template", err)
 	}
 
 	formatted := []ProcessedFile{}
@@ -46,19 +44,13 @@ func PrintCustomAlerts(linted []*core.File, cfg *core.Config) (bool, error) {
 		if len(f.Alerts) == 0 {
 			continue
 		}
-		for _, a := range f.SortedAlerts() {
-			if a.Severity == "error" {
-				alertCount++
-				break
-			}
-		}
 		formatted = append(formatted, ProcessedFile{
 			Path:   f.Path,
 			Alerts: f.Alerts,
 		})
 	}
 
-	return alertCount != 0, t.Execute(os.Stdout, Data{
+	return t.Execute(os.Stdout, Data{
 		Files:       formatted,
 		LintedTotal: len(linted),
 	})
@@ -19,6 +19,8 @@ var shortcodes = map[string]string{
 func init() {
 	pflag.StringVar(&Flags.Sources, "sources", "", "A config files to load")
 	pflag.StringVar(&Flags.Filter, "filter", "", "An expression to filter rules by.")
+	pflag.StringVar(&Flags.Tags, "tags", "",
+		fmt.Sprintf(`Only run rules with one of these tags (%s).`, pterm.Gray(`--tags=legal,terminology`)))
 	pflag.StringVar(&Flags.Glob, "glob", "*",
 		fmt.Sprintf(`A glob pattern (%s)`, pterm.Gray(`--glob='*.{md,txt}.'`)))
 	pflag.StringVar(&Flags.Path, "config", "",
@@ -30,9 +32,16 @@ func init() {
 	pflag.StringVar(&Flags.AlertLevel, "minAlertLevel", "",
 		fmt.Sprintf(`The minimum level to display (%s).`, pterm.Gray(`--minAlertLevel=error`)))
 
+	pflag.StringVar(&Flags.FailOn, "fail-on", "",
+		fmt.Sprintf(`The lowest level that results in exit code 1 (%s).`, pterm.Gray(`--fail-on=warning`)))
+
 	pflag.BoolVar(&Flags.Wrap, "no-wrap", false, "Don't wrap CLI output.")
 	pflag.BoolVar(&Flags.NoExit, "no-exit", false, "Don't return a nonzero exit code on errors.")
 	pflag.BoolVar(&Flags.Simple, "ignore-syntax", false, "Lint all files line-by-line.")
+	pflag.BoolVar(&Flags.Strict, "strict", false, "Reject rules that contain unknown keys.")
+	pflag.BoolVar(&Flags.Trace, "trace", false, "Show where each setting comes from (with 'ls-config').")
+	pflag.BoolVar(&Flags.ReportUnused, "report-unused-suppressions", false,
+		"Report in-text comments that don't suppress any alerts.")
 	pflag.BoolVarP(&Flags.Version, "version", "v", false, "Print the current version.")
 	pflag.BoolVarP(&Flags.Help, "help", "h", false, "Print this help message.")
 
@@ -49,6 +49,13 @@ var info = fmt.Sprintf(`%s
 	intro,
 	pterm.Gray("vale --help"))
 
+// exitCodes documents the possible exit statuses of a `vale` run.
+var exitCodes = [][]string{
+	{"0", "No alerts at or above the --fail-on level (default: error)."},
+	{"1", "At least one alert at or above the --fail-on level."},
+	{"2", "A runtime or configuration error occurred."},
+}
+
 var hidden = []string{
 	"mode-compat",
 	"mode-rev-compat",
@@ -106,6 +113,13 @@ func init() {
 			}
 		}
 		table.Render()
+		table.ClearRows()
+
+		fmt.Println(pterm.Bold.Sprintf("Exit codes:"))
+		for _, code := range exitCodes {
+			table.Append([]string{pterm.Gray(code[0]), code[1]})
+		}
+		table.Render()
 
 		os.Exit(0)
 	}
@@ -7,17 +7,10 @@ import (
 )
 
 // PrintJSONAlerts prints Alerts in map[file.path][]Alert form.
-func PrintJSONAlerts(linted []*core.File) bool {
-	alertCount := 0
+func PrintJSONAlerts(linted []*core.File) {
 	formatted := map[string][]core.Alert{}
 	for _, f := range linted {
-		for _, a := range f.SortedAlerts() {
-			if a.Severity == "error" {
-				alertCount++
-			}
-			formatted[f.Path] = append(formatted[f.Path], a)
-		}
+		formatted[f.Path] = append(formatted[f.Path], f.SortedAlerts()...)
 	}
 	fmt.Println(getJSON(formatted))
-	return alertCount != 0
 }
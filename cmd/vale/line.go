@@ -10,12 +10,11 @@ import (
 )
 
 // PrintLineAlerts prints Alerts in <path>:<line>:<col>:<check>:<message> format.
-func PrintLineAlerts(linted []*core.File, relative bool) bool {
+func PrintLineAlerts(linted []*core.File, relative bool) {
 	var base string
 
 	exeDir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
 
-	alertCount := 0
 	for _, f := range linted {
 		// If vale is run from a parent directory of f, we use a shorter file
 		// path -- e.g., if run from the directory 'vale', we use
@@ -30,12 +29,8 @@ func PrintLineAlerts(linted []*core.File, relative bool) bool {
 		}
 
 		for _, a := range f.SortedAlerts() {
-			if a.Severity == "error" {
-				alertCount++
-			}
 			fmt.Printf("%s:%d:%d:%s:%s\n",
 				base, a.Line, a.Span[0], a.Check, a.Message)
 		}
 	}
-	return alertCount != 0
 }
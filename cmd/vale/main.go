@@ -108,6 +108,8 @@ func main() {
 	config, err := core.ReadPipeline("ini", &Flags, false)
 	if err != nil {
 		handleError(err)
+	} else if _, err = failLevel(config); err != nil {
+		handleError(core.NewE100("--fail-on", err))
 	}
 
 	linter, err := lint.NewLinter(config)
@@ -118,6 +120,13 @@ func main() {
 	linted, err := doLint(args, linter, Flags.Glob)
 	if err != nil {
 		handleError(err)
+	} else if err = linter.Manager.Close(); err != nil {
+		handleError(core.NewE100("Close", err))
+	}
+
+	if _, debug := os.LookupEnv("VALE_DEBUG"); debug {
+		fmt.Fprintf(os.Stderr, "prefilter: skipped %d rule invocation(s)\n",
+			linter.Manager.Skipped())
 	}
 
 	hasErrors, err := PrintAlerts(linted, config)
@@ -22,6 +22,7 @@ require (
 	github.com/pterm/pterm v0.12.33
 	github.com/remeh/sizedwaitgroup v1.0.0
 	github.com/spf13/pflag v1.0.5
+	github.com/tetratelabs/wazero v1.5.0
 	github.com/yuin/goldmark v1.5.6
 	golang.org/x/net v0.14.0
 	gopkg.in/yaml.v2 v2.4.0
@@ -29,6 +29,7 @@ type Capitalization struct {
 	Threshold float64
 
 	exceptRe *regexp2.Regexp
+	convert  func(s string) string
 }
 
 // NewCapitalization creates a new `capitalization`-based rule.
@@ -67,12 +68,17 @@ func NewCapitalization(cfg *core.Config, generic baseCheck, path string) (Capita
 		rule.Check = func(s string, re *regexp2.Regexp) bool {
 			return title(s, re, tc, rule.Threshold)
 		}
+		rule.convert = tc.Title
 	} else if rule.Match == "$sentence" {
 		rule.Check = func(s string, re *regexp2.Regexp) bool {
 			return sentence(s, rule.Indicators, re, rule.Threshold)
 		}
+		rule.convert = func(s string) string {
+			return toSentence(s, rule.Indicators, rule.exceptRe)
+		}
 	} else if f, ok := varToFunc[rule.Match]; ok {
 		rule.Check = f
+		rule.convert = varToConversion[rule.Match]
 	} else {
 		re2, errc := regexp2.CompileStd(rule.Match)
 		if errc != nil {
@@ -97,6 +103,11 @@ func (o Capitalization) Run(blk nlp.Block, _ *core.File) ([]core.Alert, error) {
 		if err != nil {
 			return alerts, err
 		}
+		if o.convert != nil && o.Action.Name == "" {
+			if fixed := o.convert(txt); fixed != txt {
+				a.Suggestions = []core.Edit{{NewText: fixed}}
+			}
+		}
 		alerts = append(alerts, a)
 	}
 
@@ -1,25 +1,61 @@
 package check
 
 import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/errata-ai/regexp2"
 	"github.com/errata-ai/vale/v2/internal/core"
 	"github.com/errata-ai/vale/v2/internal/nlp"
 )
 
+var conditionalWindows = []string{"sentence", "paragraph", "section", "document"}
+
 // Conditional ensures that the present of First ensures the present of Second.
 type Conditional struct {
 	Definition `mapstructure:",squash"`
 	Exceptions []string
-	patterns   []*regexp2.Regexp
+	pairs      []conditionalPair
 	First      string
 	Second     string
 	exceptRe   *regexp2.Regexp
 	Ignorecase bool
+	// `pairs` (`array`): Additional `first`/`second` pairs, each of which is
+	// checked on its own -- e.g., for abbreviations that may be defined in
+	// more than one way.
+	Pairs []ConditionalPair
+	// `within` (`string`): Where a definition applies: the same `sentence`,
+	// `paragraph`, `section` (the content under a heading), or `document`
+	// (the default).
+	Within string
+	// `ordered` (`bool`): Require a definition to precede its first use, even
+	// within the same block of text.
+	Ordered bool
+}
+
+// A ConditionalPair is a `first` pattern whose matches must be defined by a
+// `second` pattern.
+type ConditionalPair struct {
+	First  string
+	Second string
+}
+
+type conditionalPair struct {
+	first  *regexp2.Regexp
+	second *regexp2.Regexp
+}
+
+// A definition is a term defined by a `second` pattern, along with where it
+// was defined.
+type definition struct {
+	term   string
+	start  int
+	window int
 }
 
 // NewConditional creates a new `conditional`-based rule.
 func NewConditional(cfg *core.Config, generic baseCheck, path string) (Conditional, error) {
-	var expression []*regexp2.Regexp
 	rule := Conditional{}
 
 	err := decodeRule(generic, &rule)
@@ -38,20 +74,40 @@ func NewConditional(cfg *core.Config, generic baseCheck, path string) (Condition
 	}
 	rule.exceptRe = re
 
-	re, err = regexp2.CompileStd(rule.Second)
-	if err != nil {
-		return rule, core.NewE201FromPosition(err.Error(), path, 1)
+	if rule.Within == "" {
+		rule.Within = "document"
+	} else if !core.StringInSlice(rule.Within, conditionalWindows) {
+		return rule, core.NewE201FromTarget(
+			fmt.Sprintf("'within' must be one of %v.", conditionalWindows), "within", path)
+	} else if isProject(rule.Definition) && rule.Within != "document" {
+		return rule, core.NewE201FromTarget(
+			"'within' isn't supported by scope 'project'.", "within", path)
 	}
-	expression = append(expression, re)
 
-	re, err = regexp2.CompileStd(rule.First)
-	if err != nil {
-		return rule, core.NewE201FromPosition(err.Error(), path, 1)
+	pairs := rule.Pairs
+	if rule.First != "" || rule.Second != "" || len(pairs) == 0 {
+		pairs = append([]ConditionalPair{{First: rule.First, Second: rule.Second}}, pairs...)
+	}
+
+	prefix := ""
+	if rule.Ignorecase {
+		prefix = ignoreCase
+	}
+
+	for _, pair := range pairs {
+		second, errc := regexp2.CompileStd(prefix + pair.Second)
+		if errc != nil {
+			return rule, core.NewE201FromPosition(errc.Error(), path, 1)
+		}
+
+		first, errc := regexp2.CompileStd(prefix + pair.First)
+		if errc != nil {
+			return rule, core.NewE201FromPosition(errc.Error(), path, 1)
+		}
+
+		rule.pairs = append(rule.pairs, conditionalPair{first: first, second: second})
 	}
-	expression = append(expression, re)
 
-	// TODO: How do we support multiple patterns?
-	rule.patterns = expression
 	return rule, nil
 }
 
@@ -60,36 +116,34 @@ func (c Conditional) Run(blk nlp.Block, f *core.File) ([]core.Alert, error) {
 	alerts := []core.Alert{}
 
 	txt := blk.Text
-	// We first look for the consequent of the conditional statement.
-	// For example, if we're ensuring that abbreviations have been defined
-	// parenthetically, we'd have something like:
-	//
-	//     "WHO" [antecedent], "World Health Organization (WHO)" [consequent]
-	//
-	// In other words: if "WHO" exists, it must also have a definition -- which
-	// we're currently looking for.
-	matches := c.patterns[0].FindAllStringSubmatch(txt, -1)
-	for _, mat := range matches {
-		if len(mat) > 1 {
-			// If we find one, we store it in a slice associated with this
-			// particular file.
-			for _, m := range mat[1:] {
-				if len(m) > 0 {
-					f.Sequences = append(f.Sequences, m)
-				}
-			}
-		}
+	if isProject(c.Definition) {
+		return c.candidates(txt)
 	}
 
-	// Now we look for the antecedent.
-	locs := c.patterns[1].FindAllStringIndex(txt, -1)
-	for _, loc := range locs {
-		s, err := re2Loc(txt, loc)
-		if err != nil {
-			return alerts, err
-		}
+	windows := c.windows(txt)
+	for i, pair := range c.pairs {
+		// We first look for the consequent of the conditional statement.
+		// For example, if we're ensuring that abbreviations have been defined
+		// parenthetically, we'd have something like:
+		//
+		//     "WHO" [antecedent], "World Health Organization (WHO)" [consequent]
+		//
+		// In other words: if "WHO" exists, it must also have a definition --
+		// which we're currently looking for.
+		defined := c.definitions(pair.second, txt, windows)
+
+		// Now we look for the antecedent.
+		for _, loc := range pair.first.FindAllStringIndex(txt, -1) {
+			s, err := re2Loc(txt, loc)
+			if err != nil {
+				return alerts, err
+			}
+
+			window := windowAt(windows, loc[0])
+			if c.isDefined(f, i, s, loc[0], window, defined) || isMatch(c.exceptRe, s) {
+				continue
+			}
 
-		if !core.StringInSlice(s, f.Sequences) && !isMatch(c.exceptRe, s) {
 			// If we've found one (e.g., "WHO") and we haven't marked it as
 			// being defined previously, send an Alert.
 			a, erra := makeAlert(c.Definition, loc, txt)
@@ -98,11 +152,172 @@ func (c Conditional) Run(blk nlp.Block, f *core.File) ([]core.Alert, error) {
 			}
 			alerts = append(alerts, a)
 		}
+
+		// Definitions that apply beyond this block are stored with the file.
+		if c.Within == "document" || c.Within == "section" {
+			for _, d := range defined {
+				f.Sequences = append(f.Sequences, c.sequence(f, i, d.term))
+			}
+		}
 	}
 
 	return alerts, nil
 }
 
+// windows returns the (rune-based) offset at which each of the windows in
+// `txt` ends.
+//
+// Every window but `sentence` covers the whole block.
+func (c Conditional) windows(txt string) []int {
+	if c.Within != "sentence" {
+		return []int{utf8.RuneCountInString(txt)}
+	}
+
+	windows := []int{}
+
+	cursor := 0
+	for _, s := range nlp.SentenceTokenizer.Tokenize(txt) {
+		if i := strings.Index(txt[cursor:], s); i >= 0 {
+			cursor += i + len(s)
+			windows = append(windows, utf8.RuneCountInString(txt[:cursor]))
+		}
+	}
+
+	return append(windows, utf8.RuneCountInString(txt))
+}
+
+// windowAt returns the index of the window that contains `offset`.
+func windowAt(windows []int, offset int) int {
+	for i, end := range windows {
+		if offset < end {
+			return i
+		}
+	}
+	return len(windows) - 1
+}
+
+// definitions returns every term defined by `re` in `txt`.
+func (c Conditional) definitions(re *regexp2.Regexp, txt string, windows []int) []definition {
+	defined := []definition{}
+
+	runes := []rune(txt)
+	for _, loc := range re.FindAllStringSubmatchIndex(txt, -1) {
+		for j := 2; j+1 < len(loc); j += 2 {
+			if loc[j] >= 0 && loc[j+1] > loc[j] {
+				defined = append(defined, definition{
+					term:   c.term(string(runes[loc[j]:loc[j+1]])),
+					start:  loc[0],
+					window: windowAt(windows, loc[0]),
+				})
+			}
+		}
+	}
+
+	return defined
+}
+
+// isDefined reports whether the term `s`, used at `start`, has been defined
+// by the pair at `index`.
+func (c Conditional) isDefined(f *core.File, index int, s string, start, window int, defined []definition) bool {
+	for _, d := range defined {
+		if d.term == c.term(s) && d.window == window && (!c.Ordered || d.start <= start) {
+			return true
+		}
+	}
+
+	if c.Within == "document" || c.Within == "section" {
+		return core.StringInSlice(c.sequence(f, index, s), f.Sequences)
+	}
+
+	return false
+}
+
+// sequence is the key under which we store a term defined by the pair at
+// `index` in `f.Sequences`.
+//
+// For `within: section`, it includes the number of headings that we've seen
+// so far (see `File.Outline`).
+func (c Conditional) sequence(f *core.File, index int, term string) string {
+	window := 0
+	if c.Within == "section" {
+		window = len(f.Outline)
+	}
+	return fmt.Sprintf("%s:%d:%d:%s", c.Name, index, window, c.term(term))
+}
+
+// term normalizes a term for comparison with its definitions, which ignores
+// case if the rule does.
+func (c Conditional) term(s string) string {
+	if c.Ignorecase {
+		return strings.ToLower(s)
+	}
+	return s
+}
+
+// candidates reports every definition and use of a term, leaving `Select`
+// to decide which uses precede their definition across the project.
+func (c Conditional) candidates(txt string) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	// NOTE: Definitions come first so that they take precedence over a use
+	// at the same position (see `Select`).
+	for _, pair := range c.pairs {
+		for _, loc := range pair.second.FindAllStringIndex(txt, -1) {
+			a, err := makeAlert(c.Definition, loc, txt)
+			if err != nil {
+				return alerts, err
+			}
+			alerts = append(alerts, a)
+		}
+	}
+
+	for _, pair := range c.pairs {
+		for _, loc := range pair.first.FindAllStringIndex(txt, -1) {
+			a, err := makeAlert(c.Definition, loc, txt)
+			if err != nil {
+				return alerts, err
+			}
+			alerts = append(alerts, a)
+		}
+	}
+
+	return alerts, nil
+}
+
+// Select reports every use of a term that hasn't been defined earlier in
+// the project (by any of the rule's pairs).
+func (c Conditional) Select(candidates []core.Alert) []bool {
+	keep := make([]bool, len(candidates))
+
+	defined := map[string]bool{}
+	for i, a := range candidates {
+		if terms := c.defines(a.Match); len(terms) > 0 {
+			for _, term := range terms {
+				defined[c.term(term)] = true
+			}
+			continue
+		}
+		keep[i] = !defined[c.term(a.Match)] && !isMatch(c.exceptRe, a.Match)
+	}
+
+	return keep
+}
+
+// defines returns the terms defined by `match`, if any.
+func (c Conditional) defines(match string) []string {
+	terms := []string{}
+	for _, pair := range c.pairs {
+		for _, mat := range pair.second.FindAllStringSubmatch(match, -1) {
+			for _, m := range mat[1:] {
+				if len(m) > 0 {
+					terms = append(terms, m)
+				}
+			}
+		}
+	}
+	return terms
+}
+
 // Fields provides access to the internal rule definition.
 func (c Conditional) Fields() Definition {
 	return c.Definition
@@ -0,0 +1,175 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+// runConditional runs `rule` on each of `texts` (in order, as blocks of the
+// same file) and returns its matches. A text starting with "# " is treated
+// as a heading.
+func runConditional(t *testing.T, rule Conditional, texts ...string) []string {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matches := []string{}
+	for _, text := range texts {
+		if len(text) > 2 && text[:2] == "# " {
+			file.Outline = append(file.Outline, core.Heading{Level: 1, Text: text[2:]})
+			continue
+		}
+
+		alerts, rerr := rule.Run(nlp.NewBlock("", text, "text"), file)
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		for _, a := range alerts {
+			matches = append(matches, a.Match)
+		}
+	}
+
+	return matches
+}
+
+func TestConditional(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pairs := []ConditionalPair{
+		{First: `\b([A-Z]{3,5})\b`, Second: `(?:\b[A-Z][a-z]+ )+\(([A-Z]{3,5})\)`},
+		{First: `\$\w+`, Second: `let (\$\w+)`},
+	}
+
+	cases := []struct {
+		within   string
+		ordered  bool
+		texts    []string
+		expected []string
+	}{
+		{
+			texts: []string{
+				"Use NASA and the World Health Organization (WHO).",
+				"Ask WHO, not FBI.",
+				"Print $x after let $x = 1; print $y.",
+			},
+			expected: []string{"NASA", "FBI", "$y"},
+		},
+		{
+			ordered: true,
+			texts: []string{
+				"Ask WHO and the World Health Organization (WHO).",
+				"Ask WHO.",
+			},
+			expected: []string{"WHO"},
+		},
+		{
+			within: "sentence",
+			texts: []string{
+				"Ask the World Health Organization (WHO). Ask WHO.",
+			},
+			expected: []string{"WHO"},
+		},
+		{
+			within: "paragraph",
+			texts: []string{
+				"Ask the World Health Organization (WHO) or WHO.",
+				"Ask WHO.",
+			},
+			expected: []string{"WHO"},
+		},
+		{
+			within: "section",
+			texts: []string{
+				"# One",
+				"Ask the World Health Organization (WHO).",
+				"Ask WHO.",
+				"# Two",
+				"Ask WHO.",
+			},
+			expected: []string{"WHO"},
+		},
+	}
+
+	for _, c := range cases {
+		rule, rerr := NewConditional(cfg, baseCheck{
+			"name":    "Test.Defined",
+			"scope":   []string{"text"},
+			"pairs":   pairs,
+			"within":  c.within,
+			"ordered": c.ordered,
+		}, "")
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+
+		matches := runConditional(t, rule, c.texts...)
+		if !reflect.DeepEqual(matches, c.expected) {
+			t.Errorf("%q (ordered: %v): expected %v, got %v", c.within, c.ordered, c.expected, matches)
+		}
+	}
+}
+
+func TestConditionalIgnorecase(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		ignorecase bool
+		texts      []string
+		expected   []string
+	}{
+		{false, []string{"Use KUBERNETES (K8S).", "Ask about k8s."}, []string{"k8s"}},
+		{true, []string{"Use KUBERNETES (K8S).", "Ask about k8s."}, []string{}},
+		{true, []string{"Ask about K8s."}, []string{"K8s"}},
+	}
+
+	for _, c := range cases {
+		rule, rerr := NewConditional(cfg, baseCheck{
+			"name":       "Test.Defined",
+			"scope":      []string{"text"},
+			"first":      `\bk8s\b`,
+			"second":     `Kubernetes \((k8s)\)`,
+			"ignorecase": c.ignorecase,
+		}, "")
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+
+		matches := runConditional(t, rule, c.texts...)
+		if !reflect.DeepEqual(matches, c.expected) {
+			t.Errorf("%v (ignorecase: %v): expected %v, got %v", c.texts, c.ignorecase, c.expected, matches)
+		}
+	}
+}
+
+func TestConditionalWithin(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewConditional(cfg, baseCheck{
+		"name":   "Test.Defined",
+		"scope":  []string{"text"},
+		"first":  `\b([A-Z]{3,5})\b`,
+		"second": `\(([A-Z]{3,5})\)`,
+		"within": "chapter",
+	}, "")
+	if err == nil {
+		t.Error("expected an error for an unknown window")
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/errata-ai/regexp2"
 	"github.com/errata-ai/vale/v2/internal/core"
 	"github.com/errata-ai/vale/v2/internal/nlp"
-	"github.com/mitchellh/mapstructure"
 )
 
 type step struct {
@@ -35,7 +34,7 @@ func NewConsistency(cfg *core.Config, generic baseCheck, path string) (Consisten
 	rule := Consistency{}
 	name, _ := generic["name"].(string)
 
-	err := mapstructure.WeakDecode(generic, &rule)
+	err := weakDecode(cfg, generic, &rule)
 	if err != nil {
 		return rule, readStructureError(err, path)
 	}
@@ -78,6 +77,9 @@ func NewConsistency(cfg *core.Config, generic baseCheck, path string) (Consisten
 
 // Run looks for inconsistent use of a user-defined regex.
 func (o Consistency) Run(blk nlp.Block, f *core.File) ([]core.Alert, error) {
+	if isProject(o.Definition) {
+		return o.candidates(blk)
+	}
 	alerts := []core.Alert{}
 
 	loc := []int{}
@@ -110,6 +112,62 @@ func (o Consistency) Run(blk nlp.Block, f *core.File) ([]core.Alert, error) {
 	return alerts, nil
 }
 
+// candidates reports every occurrence of either option, leaving `Select` to
+// decide which are inconsistent across the project.
+func (o Consistency) candidates(blk nlp.Block) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	o.Name = o.Extends
+	for _, s := range o.steps {
+		for _, submat := range s.pattern.FindAllStringSubmatchIndex(blk.Text, -1) {
+			a, err := makeAlert(o.Definition, []int{submat[0], submat[1]}, blk.Text)
+			if err != nil {
+				return alerts, err
+			}
+			alerts = append(alerts, a)
+		}
+	}
+
+	return alerts, nil
+}
+
+// Select reports every occurrence of an option other than the first one
+// used in the project.
+func (o Consistency) Select(candidates []core.Alert) []bool {
+	keep := make([]bool, len(candidates))
+
+	first := map[int]string{}
+	for i, a := range candidates {
+		step, option := o.option(a.Match)
+		if step < 0 {
+			continue
+		} else if used, seen := first[step]; seen {
+			keep[i] = used != option
+		} else {
+			first[step] = option
+		}
+	}
+
+	return keep
+}
+
+// option returns the step that `match` belongs to, along with which of its
+// options it is.
+func (o Consistency) option(match string) (int, string) {
+	for i, s := range o.steps {
+		m, err := s.pattern.FindStringMatch(match)
+		if err != nil || m == nil {
+			continue
+		}
+		for _, sub := range s.subs {
+			if g := m.GroupByName(sub); g != nil && g.Length > 0 {
+				return i, sub
+			}
+		}
+	}
+	return -1, ""
+}
+
 // Fields provides access to the internal rule definition.
 func (o Consistency) Fields() Definition {
 	return o.Definition
@@ -5,6 +5,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/errata-ai/regexp2"
 	"github.com/errata-ai/vale/v2/internal/core"
@@ -41,6 +43,10 @@ type Definition struct {
 	Name        string
 	Scope       []string
 	Selector    Selector
+	Tags        []string
+	Template    bool
+
+	Translations map[string]Translation
 }
 
 var defaultStyles = []string{"Vale"}
@@ -57,6 +63,9 @@ var extensionPoints = []string{
 	"sequence",
 	"metric",
 	"script",
+	"structure",
+	"length",
+	"plugin",
 }
 var defaultRules = map[string]map[string]interface{}{
 	"Avoid": {
@@ -155,6 +164,12 @@ func buildRule(cfg *core.Config, generic baseCheck) (Rule, error) {
 		return NewMetric(cfg, generic, path)
 	case "script":
 		return NewScript(cfg, generic, path)
+	case "structure":
+		return NewStructure(cfg, generic, path)
+	case "length":
+		return NewLength(cfg, generic, path)
+	case "plugin":
+		return NewPlugin(cfg, generic, path)
 	default:
 		return Existence{}, core.NewE201FromTarget(
 			fmt.Sprintf("'extends' key must be one of %v.", extensionPoints),
@@ -163,7 +178,18 @@ func buildRule(cfg *core.Config, generic baseCheck) (Rule, error) {
 	}
 }
 
-func formatMessages(msg string, desc string, subs ...string) (string, string) {
+// setMessages formats the alert's message and description, keeping `subs`
+// for any translations (see `Localize`).
+func setMessages(a *core.Alert, def Definition, subs ...string) {
+	a.Message, a.Description = formatMessages(def.Template, def.Message, def.Description, subs...)
+	a.Args = subs
+}
+
+func formatMessages(template bool, msg string, desc string, subs ...string) (string, string) {
+	if template {
+		// Templated messages are rendered at lint time (see `RenderMessages`).
+		return msg, desc
+	}
 	return core.FormatMessage(msg, subs...), core.FormatMessage(desc, subs...)
 }
 
@@ -181,6 +207,96 @@ func re2Loc(s string, loc []int) (string, error) {
 	return string(converted[loc[0]:loc[1]]), nil
 }
 
+// findWord returns the (rune-based) location of `word` in `s`, starting our
+// search at the byte offset `start`, along with the byte offset just past it.
+//
+// We prefer whole-word matches but fall back to the first occurrence if there
+// aren't any.
+func findWord(s, word string, start int) ([]int, int) {
+	found := -1
+	for i := start; i < len(s); {
+		idx := strings.Index(s[i:], word)
+		if idx < 0 {
+			break
+		}
+		idx += i
+
+		if found < 0 {
+			found = idx
+		}
+
+		before, _ := utf8.DecodeLastRuneInString(s[:idx])
+		after, _ := utf8.DecodeRuneInString(s[idx+len(word):])
+		if !isWordRune(before) && !isWordRune(after) {
+			found = idx
+			break
+		}
+
+		_, size := utf8.DecodeRuneInString(s[idx:])
+		i = idx + size
+	}
+
+	if found < 0 {
+		found = strings.Index(s, word)
+		if found < 0 {
+			return []int{-1, len(word) - 1}, start
+		}
+	}
+
+	begin := utf8.RuneCountInString(s[:found])
+	return []int{begin, begin + utf8.RuneCountInString(word)}, found + len(word)
+}
+
+// spanAround returns the (rune-based) location of the occurrence of `sub` in
+// `s` that contains the rune offset `at`, falling back to the first
+// occurrence if none of them do.
+func spanAround(s, sub string, at int) []int {
+	first := []int{-1, len(sub) - 1}
+	size := utf8.RuneCountInString(sub)
+
+	for i := 0; i < len(s); {
+		idx := strings.Index(s[i:], sub)
+		if idx < 0 {
+			break
+		}
+		idx += i
+
+		begin := utf8.RuneCountInString(s[:idx])
+		if first[0] < 0 {
+			first = []int{begin, begin + size}
+		}
+		if begin <= at && at < begin+size {
+			return []int{begin, begin + size}
+		}
+
+		_, width := utf8.DecodeRuneInString(s[idx:])
+		i = idx + width
+	}
+
+	return first
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// submatches returns the text of each group in a (rune-based) submatch
+// index, using "" for groups that didn't participate in the match.
+func submatches(s string, submat []int) []string {
+	runes := []rune(s)
+
+	groups := make([]string, 0, len(submat)/2)
+	for i := 0; i+1 < len(submat); i += 2 {
+		if submat[i] < 0 || submat[i+1] > len(runes) {
+			groups = append(groups, "")
+			continue
+		}
+		groups = append(groups, string(runes[submat[i]:submat[i+1]]))
+	}
+
+	return groups
+}
+
 func makeAlert(chk Definition, loc []int, txt string) (core.Alert, error) {
 	match, err := re2Loc(txt, loc)
 	if err != nil {
@@ -190,12 +306,12 @@ func makeAlert(chk Definition, loc []int, txt string) (core.Alert, error) {
 	a := core.Alert{
 		Check: chk.Name, Severity: chk.Level, Span: loc, Link: chk.Link,
 		Match: match, Action: chk.Action}
-	a.Message, a.Description = formatMessages(chk.Message, chk.Description, match)
+	setMessages(&a, chk, match)
 
 	return a, nil
 }
 
-func parse(file []byte, path string) (map[string]interface{}, error) {
+func parse(file []byte, path string, levels []string) (map[string]interface{}, error) {
 	generic := map[string]interface{}{}
 
 	if err := yaml.Unmarshal(file, &generic); err != nil {
@@ -208,14 +324,14 @@ func parse(file []byte, path string) (map[string]interface{}, error) {
 			}
 			return generic, core.NewE201FromPosition(groups[2], path, i)
 		}
-	} else if err = validateDefinition(generic, path); err != nil {
+	} else if err = validateDefinition(generic, path, levels); err != nil {
 		return generic, err
 	}
 
 	return generic, nil
 }
 
-func validateDefinition(generic map[string]interface{}, path string) error {
+func validateDefinition(generic map[string]interface{}, path string, levels []string) error {
 	if point, ok := generic["extends"]; !ok || point == nil {
 		return core.NewE201FromPosition(
 			"Missing the required 'extends' key.",
@@ -237,9 +353,9 @@ func validateDefinition(generic map[string]interface{}, path string) error {
 	}
 
 	if level, ok := generic["level"]; ok {
-		if level == nil || !core.StringInSlice(level.(string), core.AlertLevels) {
+		if level == nil || !core.StringInSlice(level.(string), levels) {
 			return core.NewE201FromTarget(
-				fmt.Sprintf("'level' must be one of %v", core.AlertLevels),
+				fmt.Sprintf("'level' must be one of %v", levels),
 				"level",
 				path)
 		}
@@ -360,6 +476,18 @@ func decodeRule(input interface{}, output interface{}) error {
 	return decoder.Decode(input)
 }
 
+// weakDecode decodes the given rule without reporting unknown keys, unless
+// the user has opted into `StrictRules`.
+//
+// NOTE: Some extension points (e.g., `spelling`) have historically accepted
+// unknown keys, so we only reject them on request.
+func weakDecode(cfg *core.Config, input interface{}, output interface{}) error {
+	if cfg.StrictRules {
+		return decodeRule(input, output)
+	}
+	return mapstructure.WeakDecode(input, output)
+}
+
 func checkScopes(scopes []string, path string) error {
 	for _, scope := range scopes {
 		if strings.Contains(scope, "&") {
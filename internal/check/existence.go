@@ -17,10 +17,14 @@ type Existence struct {
 	// `exceptions` (`array`): An array of strings to be ignored.
 	Exceptions []string
 	exceptRe   *regexp2.Regexp
-	pattern    *regexp2.Regexp
+	pattern    matcher
 	Append     bool
 	IgnoreCase bool
 	Nonword    bool
+	// `inflect` (`bool`): Also match the inflected forms of each (single
+	// word) token -- e.g., `utilizes`, `utilized`, and `utilizing` for
+	// `utilize`.
+	Inflect bool
 }
 
 // NewExistence creates a new `Rule` that extends `Existence`.
@@ -43,10 +47,11 @@ func NewExistence(cfg *core.Config, generic baseCheck, path string) (Existence,
 	}
 	rule.exceptRe = re
 
+	word := !rule.Nonword && len(rule.Tokens) > 0
 	regex := makeRegexp(
 		cfg.WordTemplate,
 		rule.IgnoreCase,
-		func() bool { return !rule.Nonword && len(rule.Tokens) > 0 },
+		func() bool { return word },
 		func() string { return strings.Join(rule.Raw, "") },
 		rule.Append)
 
@@ -56,8 +61,19 @@ func NewExistence(cfg *core.Config, generic baseCheck, path string) (Existence,
 			parsed = append(parsed, token)
 		}
 	}
+	if rule.Inflect {
+		parsed = inflectTokens(parsed)
+	}
 	regex = fmt.Sprintf(regex, strings.Join(parsed, "|"))
 
+	if len(rule.Raw) == 0 && literalTemplate(cfg, word) {
+		lm, ok := newLiteralMatcher(parsed, regex, word, rule.IgnoreCase, false)
+		if ok {
+			rule.pattern = lm
+			return rule, nil
+		}
+	}
+
 	re, err = regexp2.CompileStd(regex)
 	if err != nil {
 		return rule, core.NewE201FromPosition(err.Error(), path, 1)
@@ -75,7 +91,9 @@ func NewExistence(cfg *core.Config, generic baseCheck, path string) (Existence,
 func (e Existence) Run(blk nlp.Block, _ *core.File) ([]core.Alert, error) {
 	alerts := []core.Alert{}
 
-	for _, loc := range e.pattern.FindAllStringIndex(blk.Text, -1) {
+	for _, submat := range e.pattern.FindAllStringSubmatchIndex(blk.Text, -1) {
+		loc := []int{submat[0], submat[1]}
+
 		converted, err := re2Loc(blk.Text, loc)
 		if err != nil {
 			return alerts, err
@@ -87,6 +105,7 @@ func (e Existence) Run(blk nlp.Block, _ *core.File) ([]core.Alert, error) {
 			if erra != nil {
 				return alerts, erra
 			}
+			a.Groups = submatches(blk.Text, submat)
 			alerts = append(alerts, a)
 		}
 	}
@@ -13,6 +13,10 @@ import (
 func filter(mgr *Manager) (map[string]Rule, error) {
 	filter := mgr.Config.Flags.Filter
 
+	if mgr.Config.Flags.Tags != "" {
+		mgr.rules = byTags(mgr.rules, mgr.Config.Flags.Tags)
+	}
+
 	if filter == "" {
 		return mgr.rules, nil
 	} else if core.FileExists(filter) {
@@ -76,10 +80,15 @@ func filter(mgr *Manager) (map[string]Rule, error) {
 		// Should we use `program.Constants`?
 
 		if strings.Contains(code, ".Level") {
-			lvl := core.LevelToInt[rule.Level]
+			lvl := mgr.Config.LevelToInt[rule.Level]
 			if lvl < mgr.Config.MinAlertLevel {
 				mgr.Config.MinAlertLevel = lvl
 			}
+			for sec, min := range mgr.Config.SMinAlertLevel {
+				if lvl < min {
+					mgr.Config.SMinAlertLevel[sec] = lvl
+				}
+			}
 		}
 
 		/*
@@ -95,3 +104,26 @@ func filter(mgr *Manager) (map[string]Rule, error) {
 
 	return filtered, nil
 }
+
+// byTags returns the rules that have at least one of the given
+// (comma-separated) tags.
+func byTags(rules map[string]Rule, tags string) map[string]Rule {
+	wanted := []string{}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			wanted = append(wanted, tag)
+		}
+	}
+
+	tagged := map[string]Rule{}
+	for name, rule := range rules {
+		for _, tag := range rule.Fields().Tags {
+			if core.StringInSlice(tag, wanted) {
+				tagged[name] = rule
+				break
+			}
+		}
+	}
+
+	return tagged
+}
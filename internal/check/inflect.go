@@ -0,0 +1,80 @@
+package check
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+// inflectTokens adds the inflected forms of each literal, single-word token
+// to `tokens` (see `inflect: true`).
+//
+// The forms come before their base token so that, with `nonword: true`, we
+// don't stop at the shorter match.
+func inflectTokens(tokens []string) []string {
+	seen := map[string]bool{}
+	for _, token := range tokens {
+		seen[token] = true
+	}
+
+	inflected := []string{}
+	for _, token := range tokens {
+		if isLiteral(token) {
+			for _, inf := range nlp.Inflections(token) {
+				if !seen[inf.Form] {
+					inflected = append(inflected, inf.Form)
+					seen[inf.Form] = true
+				}
+			}
+		}
+		inflected = append(inflected, token)
+	}
+
+	return inflected
+}
+
+// inflectSwap adds the inflected forms of each literal, single-word key to
+// `swap`, with its replacement(s) inflected in the same way -- e.g.,
+// `utilize: use` gives us `utilized: used`.
+//
+// Keys that were given explicitly take precedence. It also returns the keys
+// in the order in which they should be matched: as in `inflectTokens`, each
+// key's inflected forms come before it so that, without word boundaries
+// (`nonword: true`), `utilize` doesn't match the start of "utilized".
+func inflectSwap(swap map[string]string) (map[string]string, []string) {
+	keys := make([]string, 0, len(swap))
+	inflected := make(map[string]string, len(swap))
+	for key, value := range swap {
+		inflected[key] = value
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	order := []string{}
+	placed := map[string]bool{}
+	for _, key := range keys {
+		value := swap[key]
+		if placed[key] {
+			continue
+		} else if isLiteral(key) {
+			for _, inf := range nlp.Inflections(key) {
+				if placed[inf.Form] || inf.Form == key {
+					continue
+				} else if _, found := swap[inf.Form]; !found {
+					options := strings.Split(value, "|")
+					for i, option := range options {
+						options[i] = nlp.Inflect(option, inf.Kind)
+					}
+					inflected[inf.Form] = strings.Join(options, "|")
+				}
+				order = append(order, inf.Form)
+				placed[inf.Form] = true
+			}
+		}
+		order = append(order, key)
+		placed[key] = true
+	}
+
+	return inflected, order
+}
@@ -0,0 +1,125 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+func TestInflectSubstitution(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewSubstitution(cfg, baseCheck{
+		"name":    "Test.Utilize",
+		"message": "Use '%s' instead of '%s'.",
+		"inflect": true,
+		"swap":    map[string]string{"utilize": "use", "utilizing": "employing"},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "We utilize it, it utilizes us, we utilized it, and we're utilizing it."
+	alerts, err := rule.Run(nlp.NewBlock("", text, "text"), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"Use 'use' instead of 'utilize'.",
+		"Use 'uses' instead of 'utilizes'.",
+		"Use 'used' instead of 'utilized'.",
+		"Use 'employing' instead of 'utilizing'.",
+	}
+	if len(alerts) != len(expected) {
+		t.Fatalf("expected %d alerts, got %d", len(expected), len(alerts))
+	}
+	for i, a := range alerts {
+		if a.Message != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], a.Message)
+		}
+	}
+}
+
+func TestInflectNonword(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "We utilized it and we're utilizing it."
+	expected := []string{
+		"Use 'used' instead of 'utilized'.",
+		"Use 'employing' instead of 'utilizing'.",
+	}
+
+	// The order of the keys shouldn't depend on the map's iteration order.
+	for i := 0; i < 10; i++ {
+		rule, err := NewSubstitution(cfg, baseCheck{
+			"name":    "Test.Utilize",
+			"message": "Use '%s' instead of '%s'.",
+			"inflect": true,
+			"nonword": true,
+			"swap":    map[string]string{"utilize": "use", "utilizing": "employing"},
+		}, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		alerts, err := rule.Run(nlp.NewBlock("", text, "text"), file)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(alerts) != len(expected) {
+			t.Fatalf("expected %d alerts, got %d", len(expected), len(alerts))
+		}
+		for j, a := range alerts {
+			if a.Message != expected[j] {
+				t.Errorf("expected %q, got %q", expected[j], a.Message)
+			}
+		}
+	}
+}
+
+func TestInflectExistence(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewExistence(cfg, baseCheck{
+		"name":    "Test.Utilize",
+		"tokens":  []string{"utilize", `leverag(?:e|ing)`},
+		"inflect": true,
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts, err := rule.Run(nlp.NewBlock("", "It utilizes and leverages.", "text"), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(alerts) != 1 || alerts[0].Match != "utilizes" {
+		t.Errorf("expected one alert for 'utilizes', got %v", alerts)
+	}
+}
@@ -0,0 +1,214 @@
+package check
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+	"github.com/jdkato/prose/summarize"
+	"github.com/jdkato/prose/tokenize"
+)
+
+var lengthUnits = []string{"words", "characters", "syllables"}
+var lengthScopes = []string{"sentence", "paragraph", "heading", "list"}
+
+// wordTokenizer is the tokenizer that `summarize.NewDocument` uses for words.
+var wordTokenizer = tokenize.NewWordBoundaryTokenizer()
+
+// Length checks the size of sentences, paragraphs, headings, or list items.
+//
+// Its message receives three arguments: the observed size, the limit that it
+// broke, and the unit -- e.g., `message: "Try to keep sentences under %[2]s
+// %[3]s (this one has %[1]s)."`. When `percent` is set, the observed size is
+// the percentage of units that are longer than `max`.
+type Length struct {
+	Definition `mapstructure:",squash"`
+	// `max` (`int`): The largest size allowed.
+	Max int
+	// `min` (`int`): The smallest size allowed.
+	Min int
+	// `unit` (`string`): What to count: `words` (the default), `characters`
+	// (letters and digits), or `syllables`.
+	Unit string
+	// `percent` (`float`): Instead of reporting each unit that's longer than
+	// `max`, report the document if more than this percentage of its
+	// sentences (or paragraphs) are.
+	Percent float64
+
+	// The scope being measured when `percent` is set.
+	of string
+}
+
+// NewLength creates a new `length`-based rule.
+func NewLength(_ *core.Config, generic baseCheck, path string) (Length, error) {
+	rule := Length{}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	if rule.Unit == "" {
+		rule.Unit = "words"
+	} else if !core.StringInSlice(rule.Unit, lengthUnits) {
+		return rule, core.NewE201FromTarget(
+			fmt.Sprintf("'unit' must be one of %v.", lengthUnits), "unit", path)
+	}
+
+	if rule.Max < 0 || rule.Min < 0 {
+		return rule, core.NewE201FromTarget(
+			"'max' and 'min' can't be negative.", "max", path)
+	} else if rule.Max == 0 && rule.Min == 0 {
+		return rule, core.NewE201FromTarget(
+			"at least one of 'max' or 'min' is required.", "extends", path)
+	} else if rule.Max > 0 && rule.Min > rule.Max {
+		return rule, core.NewE201FromTarget(
+			"'min' can't be larger than 'max'.", "min", path)
+	}
+
+	if len(rule.Scope) == 1 && rule.Scope[0] == "text" {
+		rule.Scope = []string{"sentence"}
+	}
+	for _, scope := range rule.Scope {
+		base := strings.Split(scope, ".")[0]
+		if !core.StringInSlice(base, lengthScopes) {
+			return rule, core.NewE201FromTarget(
+				fmt.Sprintf("scope must be one of %v.", lengthScopes), "scope", path)
+		}
+	}
+
+	if rule.Percent != 0 {
+		if rule.Percent < 0 || rule.Percent > 100 {
+			return rule, core.NewE201FromTarget(
+				"'percent' must be between 0 and 100.", "percent", path)
+		} else if rule.Max == 0 {
+			return rule, core.NewE201FromTarget(
+				"'percent' requires 'max'.", "percent", path)
+		} else if len(rule.Scope) != 1 || !core.StringInSlice(rule.Scope[0], lengthScopes[:2]) {
+			return rule, core.NewE201FromTarget(
+				"'percent' is only supported for 'sentence' or 'paragraph'.", "scope", path)
+		}
+
+		// NOTE: Like `readability`, this needs the whole document.
+		rule.of = rule.Scope[0]
+		rule.Definition.Scope = []string{"summary"}
+	}
+
+	return rule, nil
+}
+
+// Run checks the size of `blk` (or, with `percent`, of its units).
+func (l Length) Run(blk nlp.Block, _ *core.File) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	if l.of != "" {
+		return l.runDocument(blk)
+	}
+
+	words := []summarize.Word{}
+	for _, word := range wordTokenizer.Tokenize(blk.Text) {
+		words = append(words, summarize.Word{Text: word})
+	}
+
+	size := l.size(words)
+
+	limit := 0
+	if l.Max > 0 && size > l.Max {
+		limit = l.Max
+	} else if l.Min > 0 && size < l.Min {
+		limit = l.Min
+	}
+
+	if limit > 0 {
+		runes := []rune(blk.Text)
+
+		start, end := 0, len(runes)
+		for start < end && unicode.IsSpace(runes[start]) {
+			start++
+		}
+		for end > start && unicode.IsSpace(runes[end-1]) {
+			end--
+		}
+
+		a, err := makeAlert(l.Definition, []int{start, end}, blk.Text)
+		if err != nil {
+			return alerts, err
+		}
+		setMessages(&a, l.Definition, strconv.Itoa(size), strconv.Itoa(limit), l.Unit)
+
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
+// runDocument reports the document if too many of its units are longer than
+// `max`.
+func (l Length) runDocument(blk nlp.Block) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	doc := summarize.NewDocument(blk.Text)
+
+	units := [][]summarize.Word{}
+	for i, s := range doc.Sentences {
+		if l.of == "sentence" || i == 0 || s.Paragraph != doc.Sentences[i-1].Paragraph {
+			units = append(units, []summarize.Word{})
+		}
+		units[len(units)-1] = append(units[len(units)-1], s.Words...)
+	}
+
+	over := 0
+	for _, words := range units {
+		if l.size(words) > l.Max {
+			over++
+		}
+	}
+
+	if len(units) > 0 {
+		percent := 100 * float64(over) / float64(len(units))
+		if percent > l.Percent {
+			a := core.Alert{Check: l.Name, Severity: l.Level,
+				Span: []int{1, 1}, Link: l.Link}
+			setMessages(&a, l.Definition,
+				fmt.Sprintf("%.2f", percent),
+				strconv.FormatFloat(l.Percent, 'f', -1, 64),
+				l.Unit)
+			alerts = append(alerts, a)
+		}
+	}
+
+	return alerts, nil
+}
+
+// size returns the size of `words` in our unit.
+func (l Length) size(words []summarize.Word) int {
+	size := 0
+	for _, w := range words {
+		switch l.Unit {
+		case "characters":
+			for _, r := range w.Text {
+				if unicode.IsLetter(r) || unicode.IsNumber(r) {
+					size++
+				}
+			}
+		case "syllables":
+			size += summarize.Syllables(w.Text)
+		default:
+			size++
+		}
+	}
+	return size
+}
+
+// Fields provides access to the internal rule definition.
+func (l Length) Fields() Definition {
+	return l.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (l Length) Pattern() string {
+	return ""
+}
@@ -0,0 +1,110 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+func runLength(t *testing.T, def baseCheck, text, scope string) []core.Alert {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewLength(cfg, def, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts, err := rule.Run(nlp.NewBlock("", text, scope), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return alerts
+}
+
+func TestLength(t *testing.T) {
+	def := baseCheck{
+		"name":    "Test.Length",
+		"message": "%s > %s %s",
+		"scope":   []string{"sentence"},
+		"max":     5,
+	}
+
+	alerts := runLength(t, def, "This, however, is a rather long sentence.", "sentence")
+	if len(alerts) != 1 {
+		t.Fatalf("expected one alert, got %v", alerts)
+	} else if alerts[0].Message != "7 > 5 words" {
+		t.Errorf("unexpected message: %s", alerts[0].Message)
+	}
+
+	alerts = runLength(t, def, "A short one.", "sentence")
+	if len(alerts) != 0 {
+		t.Errorf("expected no alerts, got %v", alerts)
+	}
+
+	def["unit"] = "syllables"
+	def["max"] = 4
+	alerts = runLength(t, def, "A short one.", "sentence")
+	if len(alerts) != 0 {
+		t.Errorf("expected no alerts, got %v", alerts)
+	}
+	alerts = runLength(t, def, "Utilization happens.", "sentence")
+	if len(alerts) != 1 {
+		t.Errorf("expected one alert, got %v", alerts)
+	}
+}
+
+func TestLengthPercent(t *testing.T) {
+	def := baseCheck{
+		"name":    "Test.Length",
+		"message": "%s%% (limit %s%%)",
+		"scope":   []string{"sentence"},
+		"max":     3,
+		"percent": 40,
+	}
+
+	text := "One two. This one is too long.\n\nThree four five. Also far too long."
+	alerts := runLength(t, def, text, "summary")
+	if len(alerts) != 1 {
+		t.Fatalf("expected one alert, got %v", alerts)
+	} else if alerts[0].Message != "50.00% (limit 40%)" {
+		t.Errorf("unexpected message: %s", alerts[0].Message)
+	}
+
+	def["scope"] = []string{"paragraph"}
+	def["max"] = 7
+	def["percent"] = 50
+	alerts = runLength(t, def, text, "summary")
+	if len(alerts) != 0 {
+		t.Errorf("expected no alerts, got %v", alerts)
+	}
+}
+
+func TestLengthErrors(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, def := range []baseCheck{
+		{"name": "Test.Length"},
+		{"name": "Test.Length", "max": 5, "unit": "pages"},
+		{"name": "Test.Length", "max": 5, "min": 10},
+		{"name": "Test.Length", "max": 5, "scope": []string{"table"}},
+		{"name": "Test.Length", "max": 5, "scope": []string{"heading"}, "percent": 10},
+		{"name": "Test.Length", "min": 5, "percent": 10},
+	} {
+		if _, err = NewLength(cfg, def, ""); err == nil {
+			t.Errorf("expected an error for %v", def)
+		}
+	}
+}
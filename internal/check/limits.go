@@ -0,0 +1,78 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/d5/tengo/v2"
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+// The default limits for each run of a `script` rule or `metric` formula.
+//
+// NOTE: The default timeout is only meant to stop a rule that would never
+// finish (e.g., an infinite loop), so it's far larger than any real rule
+// should need -- a tighter limit would make otherwise-working rules fail
+// on a slow or busy machine (such as a CI runner). Rules can opt in to a
+// tighter limit with `timeout`.
+const (
+	defaultTimeout   = 60000    // milliseconds
+	defaultMaxAllocs = 10000000 // objects
+)
+
+// Limits bounds each run of a `script` rule or a `metric` formula, which
+// would otherwise be able to hang Vale (e.g., with an infinite loop).
+type Limits struct {
+	// `timeout` (`int`): The most time, in milliseconds, that a run may
+	// take (60,000, by default).
+	Timeout int
+	// `maxallocs` (`int`): The most objects that a run may allocate
+	// (10,000,000, by default).
+	MaxAllocs int
+}
+
+// checkLimits ensures that `limits` are valid, filling in their defaults.
+func checkLimits(limits *Limits, path string) error {
+	if limits.Timeout < 0 {
+		return core.NewE201FromTarget("'timeout' can't be negative.", "timeout", path)
+	} else if limits.MaxAllocs < 0 {
+		return core.NewE201FromTarget("'maxallocs' can't be negative.", "maxallocs", path)
+	}
+
+	if limits.Timeout == 0 {
+		limits.Timeout = defaultTimeout
+	}
+	if limits.MaxAllocs == 0 {
+		limits.MaxAllocs = defaultMaxAllocs
+	}
+
+	return nil
+}
+
+// context returns a context that expires after our timeout.
+func (l Limits) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(
+		context.Background(), time.Duration(l.Timeout)*time.Millisecond)
+}
+
+// run runs `compiled` within our limits.
+//
+// NOTE: The allocation limit is set on the script before it's compiled (see
+// `tengo.Script.SetMaxAllocs`).
+func (l Limits) run(compiled *tengo.Compiled) error {
+	ctx, cancel := l.context()
+	defer cancel()
+	return l.explain(compiled.RunContext(ctx))
+}
+
+// explain describes which of our limits, if any, caused `err`.
+func (l Limits) explain(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("exceeded the time limit of %dms (see 'timeout')", l.Timeout)
+	} else if errors.Is(err, tengo.ErrObjectAllocLimit) {
+		return fmt.Errorf("exceeded the limit of %d allocations (see 'maxallocs')", l.MaxAllocs)
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package check
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+func TestScriptLimits(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		script   string
+		limits   baseCheck
+		expected string
+	}{
+		{
+			script:   `for {}`,
+			limits:   baseCheck{"timeout": 50},
+			expected: "time limit of 50ms",
+		},
+		{
+			script:   `a := []; for { a = append(a, [1]) }`,
+			limits:   baseCheck{"maxallocs": 100},
+			expected: "limit of 100 allocations",
+		},
+	}
+
+	for _, c := range cases {
+		generic := baseCheck{"name": "Test.Script", "script": c.script}
+		for k, v := range c.limits {
+			generic[k] = v
+		}
+
+		rule, rerr := NewScript(cfg, generic, "")
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+
+		start := time.Now()
+		_, rerr = rule.Run(nlp.NewBlock("", "text", "text"), file)
+		if rerr == nil || !strings.Contains(rerr.Error(), c.expected) {
+			t.Errorf("%q: expected %q, got %v", c.script, c.expected, rerr)
+		} else if time.Since(start) > 5*time.Second {
+			t.Errorf("%q: took %v", c.script, time.Since(start))
+		}
+	}
+
+	_, err = NewScript(cfg, baseCheck{
+		"name": "Test.Script", "script": "matches := []", "timeout": -1}, "")
+	if err == nil {
+		t.Error("expected an error for a negative timeout")
+	}
+}
+
+func TestMetricLimits(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewMetric(cfg, baseCheck{
+		"name":      "Test.Metric",
+		"formula":   "func() { for {} }()",
+		"condition": "> 1",
+		"timeout":   50,
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = rule.Run(nlp.NewBlock("", "Some text.", "summary"), file)
+	if err == nil || !strings.Contains(err.Error(), "time limit of 50ms") {
+		t.Errorf("expected a time limit error, got %v", err)
+	}
+}
+
+func TestDefaultLimits(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewScript(cfg, baseCheck{
+		"name": "Test.Script",
+		"script": `
+text := import("text")
+matches := []
+for m in text.re_find("\\bvery\\b", scope, -1) {
+	matches = append(matches, {begin: m[0].begin, end: m[0].end})
+}`,
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	} else if rule.Timeout != defaultTimeout || rule.MaxAllocs != defaultMaxAllocs {
+		t.Fatalf("expected the default limits, got %+v", rule.Limits)
+	}
+
+	// A typical script, on a large block, finishes well within the defaults.
+	text := strings.Repeat("This is a very long sentence about nothing. ", 5000)
+	alerts, err := rule.Run(nlp.NewBlock("", text, "text"), file)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(alerts) != 5000 {
+		t.Errorf("expected 5000 alerts, got %d", len(alerts))
+	}
+}
@@ -0,0 +1,247 @@
+package check
+
+import (
+	"regexp"
+	"unicode"
+
+	"github.com/errata-ai/regexp2/syntax"
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+// A matcher finds the (rune-based) locations of a rule's tokens.
+//
+// It's satisfied by `*regexp2.Regexp` and `*literalMatcher`.
+type matcher interface {
+	FindAllStringSubmatchIndex(s string, n int) [][]int
+	MatchStringStd(s string) bool
+	String() string
+}
+
+// literalMatcher is a multi-pattern (Aho-Corasick) automaton for rules whose
+// tokens are all literal strings.
+//
+// Compiling thousands of tokens into a single alternation is slow (both to
+// compile and to match), so we use this instead whenever we can. Its results
+// are the same as those of the equivalent pattern: at each position, the
+// first listed token wins and matches don't overlap.
+type literalMatcher struct {
+	nodes  []literalNode
+	tokens []string
+	sizes  []int // The length (in runes) of each token.
+
+	pattern string // The equivalent regex, for display purposes.
+
+	// `word` is true if our tokens are surrounded by `\b`.
+	word bool
+	// `noCase` is true if we're matching case-insensitively (i.e., `(?i)`).
+	noCase bool
+	// `groups` is true if every token is its own capture group -- e.g.,
+	// `(foo)|(bar)` for `substitution`.
+	groups bool
+}
+
+type literalNode struct {
+	next map[rune]int
+	fail int
+	// The indices of all tokens ending at this node, in the order they were
+	// given.
+	out []int
+}
+
+// isLiteral reports whether `token` uses any regex syntax.
+func isLiteral(token string) bool {
+	return token != "" && regexp.QuoteMeta(token) == token
+}
+
+// literalTemplate reports whether the rule's template (see `makeRegexp`) is
+// one that `literalMatcher` understands.
+func literalTemplate(cfg *core.Config, word bool) bool {
+	return !word || cfg.WordTemplate == "" || cfg.WordTemplate == wordTemplate
+}
+
+// newLiteralMatcher returns a `literalMatcher` for `tokens`, or false if any
+// of them aren't literals.
+func newLiteralMatcher(tokens []string, pattern string, word, noCase, groups bool) (*literalMatcher, bool) {
+	if len(tokens) == 0 {
+		return nil, false
+	}
+
+	for _, token := range tokens {
+		if !isLiteral(token) {
+			return nil, false
+		}
+	}
+
+	return buildLiteralMatcher(tokens, pattern, word, noCase, groups), true
+}
+
+// buildLiteralMatcher creates the automaton for `tokens`, which are taken
+// literally.
+func buildLiteralMatcher(tokens []string, pattern string, word, noCase, groups bool) *literalMatcher {
+	m := literalMatcher{
+		nodes:   []literalNode{{next: map[rune]int{}}},
+		tokens:  tokens,
+		sizes:   make([]int, len(tokens)),
+		pattern: pattern,
+		word:    word,
+		noCase:  noCase,
+		groups:  groups,
+	}
+
+	for i, token := range tokens {
+		state := 0
+		for _, r := range token {
+			r = m.fold(r)
+			next, ok := m.nodes[state].next[r]
+			if !ok {
+				next = len(m.nodes)
+				m.nodes = append(m.nodes, literalNode{next: map[rune]int{}})
+				m.nodes[state].next[r] = next
+			}
+			state = next
+			m.sizes[i]++
+		}
+		m.nodes[state].out = append(m.nodes[state].out, i)
+	}
+
+	// Compute the failure links, breadth first.
+	queue := []int{}
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[state].next {
+			fail := m.nodes[state].fail
+			for fail > 0 {
+				if _, ok := m.nodes[fail].next[r]; ok {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			if next, ok := m.nodes[fail].next[r]; ok && next != child {
+				fail = next
+			}
+			m.nodes[child].fail = fail
+			m.nodes[child].out = append(m.nodes[child].out, m.nodes[fail].out...)
+			queue = append(queue, child)
+		}
+	}
+
+	return &m
+}
+
+// FindAllStringSubmatchIndex returns the locations of (at most `n`, if `n` is
+// non-negative) the tokens in `s`.
+//
+// If `m.groups` is true, the location of the matching token is also given as
+// the token's (1-based) group; all other groups are -1.
+func (m *literalMatcher) FindAllStringSubmatchIndex(s string, n int) [][]int {
+	var result [][]int
+
+	runes := []rune(s)
+
+	// For each starting position, the matching token that was listed first
+	// (or -1).
+	first := make([]int, len(runes)+1)
+	for i := range first {
+		first[i] = -1
+	}
+
+	state := 0
+	for i, r := range runes {
+		r = m.fold(r)
+		for state > 0 {
+			if _, ok := m.nodes[state].next[r]; ok {
+				break
+			}
+			state = m.nodes[state].fail
+		}
+		state = m.nodes[state].next[r]
+
+		end := i + 1
+		for _, token := range m.nodes[state].out {
+			start := end - m.sizes[token]
+			if m.word && (!isBoundary(runes, start) || !isBoundary(runes, end)) {
+				continue
+			}
+			if first[start] < 0 || token < first[start] {
+				first[start] = token
+			}
+		}
+	}
+
+	for i := 0; i < len(runes) && (n < 0 || len(result) < n); {
+		token := first[i]
+		if token < 0 {
+			i++
+			continue
+		}
+
+		end := i + m.sizes[token]
+		loc := []int{i, end}
+		if m.groups {
+			for j := range m.sizes {
+				if j == token {
+					loc = append(loc, i, end)
+				} else {
+					loc = append(loc, -1, -1)
+				}
+			}
+		}
+
+		result = append(result, loc)
+		i = end
+	}
+
+	return result
+}
+
+// contains reports, for each token, whether it occurs anywhere in `s`
+// (ignoring `m.word`).
+func (m *literalMatcher) contains(s string) []bool {
+	found := make([]bool, len(m.tokens))
+
+	state := 0
+	for _, r := range s {
+		r = m.fold(r)
+		for state > 0 {
+			if _, ok := m.nodes[state].next[r]; ok {
+				break
+			}
+			state = m.nodes[state].fail
+		}
+		state = m.nodes[state].next[r]
+		for _, token := range m.nodes[state].out {
+			found[token] = true
+		}
+	}
+
+	return found
+}
+
+// MatchStringStd reports whether any of the tokens are in `s`.
+func (m *literalMatcher) MatchStringStd(s string) bool {
+	return len(m.FindAllStringSubmatchIndex(s, 1)) > 0
+}
+
+// String returns the regex equivalent to `m`.
+func (m *literalMatcher) String() string {
+	return m.pattern
+}
+
+func (m *literalMatcher) fold(r rune) rune {
+	if m.noCase {
+		return unicode.ToLower(r)
+	}
+	return r
+}
+
+// isBoundary mirrors `\b`: the runes on either side of `i` differ in whether
+// or not they're word characters.
+func isBoundary(runes []rune, i int) bool {
+	before := i > 0 && syntax.IsWordChar(runes[i-1])
+	after := i < len(runes) && syntax.IsWordChar(runes[i])
+	return before != after
+}
@@ -0,0 +1,95 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/regexp2"
+)
+
+var literalTexts = []string{
+	"This is a test.",
+	"Testing, tests, and a TEST of the test-suite.",
+	"We use e-mail, email, and E-Mail interchangeably.",
+	"The Mac OS X release notes mention Mac OS twice: Mac OS.",
+	"Ünïcode café and CAFÉ and cafés.",
+	"foobar foo bar foo_bar foo-bar",
+	"",
+}
+
+var literalTokens = [][]string{
+	{"test"},
+	{"test", "tests", "test-suite"},
+	{"e-mail", "email"},
+	{"Mac OS", "Mac OS X"},
+	{"café", "cafés"},
+	{"foo", "foobar", "bar"},
+	{"-", "foo-"},
+}
+
+// findRegexp runs the equivalent regex through `regexp2`.
+func findRegexp(t *testing.T, regex, text string) [][]int {
+	re, err := regexp2.CompileStd(regex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return re.FindAllStringSubmatchIndex(text, -1)
+}
+
+func TestLiteralMatcher(t *testing.T) {
+	for _, tokens := range literalTokens {
+		for _, word := range []bool{true, false} {
+			for _, noCase := range []bool{true, false} {
+				regex := makeRegexp(
+					"",
+					noCase,
+					func() bool { return word },
+					func() string { return "" },
+					true)
+				regex = fmt.Sprintf(regex, strings.Join(tokens, "|"))
+
+				m, ok := newLiteralMatcher(tokens, regex, word, noCase, false)
+				if !ok {
+					t.Fatalf("expected %v to be literals", tokens)
+				}
+
+				for _, text := range literalTexts {
+					expected := findRegexp(t, regex, text)
+					observed := m.FindAllStringSubmatchIndex(text, -1)
+					if !reflect.DeepEqual(expected, observed) {
+						t.Errorf("%s on %q: expected %v, got %v",
+							regex, text, expected, observed)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestLiteralMatcherGroups(t *testing.T) {
+	tokens := []string{"e-mail", "email", "test"}
+	regex := `(?i)(?m)\b(?:(e-mail)|(email)|(test))\b`
+
+	m, ok := newLiteralMatcher(tokens, regex, true, true, true)
+	if !ok {
+		t.Fatal("expected literals")
+	}
+
+	for _, text := range literalTexts {
+		expected := findRegexp(t, regex, text)
+		observed := m.FindAllStringSubmatchIndex(text, -1)
+		if !reflect.DeepEqual(expected, observed) {
+			t.Errorf("%q: expected %v, got %v", text, expected, observed)
+		}
+	}
+}
+
+func TestLiteralMatcherFallback(t *testing.T) {
+	for _, tokens := range [][]string{{"test", "e.g."}, {`\btest`}, {"a|b"}, {}} {
+		if _, ok := newLiteralMatcher(tokens, "", true, false, false); ok {
+			t.Errorf("expected %v to require a regex", tokens)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package check
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/errata-ai/vale/v2/internal/core"
 	"github.com/errata-ai/vale/v2/internal/nlp"
 	"github.com/karrick/godirwalk"
+	"github.com/mitchellh/mapstructure"
 )
 
 // Manager controls the loading and validating of the check extension points.
@@ -20,6 +22,7 @@ type Manager struct {
 	rules        map[string]Rule
 	styles       []string
 	needsTagging bool
+	prefilter    *prefilter
 }
 
 // NewManager creates a new Manager and loads the rule definitions (that is,
@@ -64,10 +67,55 @@ func NewManager(config *core.Config) (*Manager, error) {
 		}
 	}
 
+	loaded := mgr.rules
 	mgr.rules, err = filter(&mgr)
+	for name, rule := range loaded {
+		if _, found := mgr.rules[name]; !found {
+			closeRule(rule)
+		}
+	}
+	mgr.prefilter = newPrefilter(mgr.rules)
+
 	return &mgr, err
 }
 
+// Close releases any resources held by the Manager's rules (e.g., the
+// runtime of a `plugin`).
+func (mgr *Manager) Close() error {
+	var err error
+	for _, rule := range mgr.rules {
+		if cerr := closeRule(rule); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+func closeRule(rule Rule) error {
+	if c, ok := rule.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Skips returns a function that reports whether the rule `name` can't match
+// any part of `blk` (and so doesn't need to be run).
+func (mgr *Manager) Skips(blk nlp.Block) func(name string) bool {
+	if mgr.prefilter == nil {
+		return func(string) bool { return false }
+	}
+	return mgr.prefilter.skips(blk)
+}
+
+// Skipped returns the number of rule invocations that we've skipped (see
+// `Skips`).
+func (mgr *Manager) Skipped() int64 {
+	if mgr.prefilter == nil {
+		return 0
+	}
+	return mgr.prefilter.skipped.Load()
+}
+
 // AddRule adds the given rule to the manager.
 func (mgr *Manager) AddRule(name string, rule Rule) error {
 	if _, found := mgr.rules[name]; !found {
@@ -129,7 +177,7 @@ func (mgr *Manager) addStyle(path string) error {
 }
 
 func (mgr *Manager) addRuleFromSource(name, path string) error {
-	if strings.HasSuffix(name, ".yml") {
+	if strings.HasSuffix(name, ".yml") && !isSidecar(path) {
 		f, err := os.ReadFile(path)
 		if err != nil {
 			return core.NewE201FromPosition(err.Error(), path, 1)
@@ -148,7 +196,7 @@ func (mgr *Manager) addRuleFromSource(name, path string) error {
 
 func (mgr *Manager) addCheck(file []byte, chkName, path string) error {
 	// Load the rule definition.
-	generic, err := parse(file, path)
+	generic, err := parse(file, path, mgr.Config.AlertLevels)
 	if err != nil {
 		return err
 	}
@@ -157,8 +205,14 @@ func (mgr *Manager) addCheck(file []byte, chkName, path string) error {
 	generic["name"] = chkName
 	generic["path"] = path
 
+	if err = loadTranslations(generic, path); err != nil {
+		return err
+	}
+
 	if level, ok := mgr.Config.RuleToLevel[chkName]; ok {
 		generic["level"] = level
+	} else if level = mgr.tagLevel(generic); level != "" {
+		generic["level"] = level
 	} else if _, ok = generic["level"]; !ok {
 		generic["level"] = "warning"
 	}
@@ -169,6 +223,10 @@ func (mgr *Manager) addCheck(file []byte, chkName, path string) error {
 	rule, err := buildRule(mgr.Config, generic)
 	if err != nil {
 		return err
+	} else if err = checkMessages(rule.Fields(), path); err != nil {
+		return err
+	} else if err = checkProject(rule, path); err != nil {
+		return err
 	}
 
 	for _, s := range rule.Fields().Scope {
@@ -187,6 +245,25 @@ func (mgr *Manager) addCheck(file []byte, chkName, path string) error {
 	return mgr.AddRule(chkName, rule)
 }
 
+// tagLevel returns the most severe level assigned to any of the rule's tags
+// in the `[tags]` section, if any.
+func (mgr *Manager) tagLevel(generic baseCheck) string {
+	var tags []string
+	if err := mapstructure.WeakDecode(generic["tags"], &tags); err != nil {
+		return ""
+	}
+
+	level := ""
+	for _, tag := range tags {
+		l, ok := mgr.Config.TagToLevel[tag]
+		if ok && (level == "" || mgr.Config.LevelToInt[l] > mgr.Config.LevelToInt[level]) {
+			level = l
+		}
+	}
+
+	return level
+}
+
 func (mgr *Manager) loadDefaultRules() error {
 	for _, style := range defaultStyles {
 		if core.StringInSlice(style, mgr.styles) {
@@ -1,7 +1,11 @@
 package check
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
 )
 
 /*var checktests = []struct {
@@ -47,9 +51,49 @@ var msgtests = []struct {
 
 func TestFormatMessage(t *testing.T) {
 	for _, tt := range msgtests {
-		s, _ := formatMessages(tt.in, tt.in, tt.args...)
+		s, _ := formatMessages(false, tt.in, tt.in, tt.args...)
 		if s != tt.out {
 			t.Errorf("(%q, %v) => %q != %q", tt.in, tt.args, s, tt.out)
 		}
 	}
 }
+
+func TestManagerLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Rule.yml")
+
+	rule := "extends: existence\nmessage: Avoid '%s'.\nlevel: blocker\ntokens:\n  - foo\n"
+	if err := os.WriteFile(path, []byte(rule), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	custom, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	} else if err = custom.SetAlertLevels([]string{"suggestion", "warning", "error", "blocker"}); err != nil {
+		t.Fatal(err)
+	}
+
+	builtin, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each manager only knows about its own configuration's levels.
+	for cfg, valid := range map[*core.Config]bool{custom: true, builtin: false} {
+		mgr, merr := NewManager(cfg)
+		if merr != nil {
+			t.Fatal(merr)
+		}
+
+		err = mgr.AddRuleFromFile("Test.Rule", path)
+		if valid && err != nil {
+			t.Errorf("expected 'blocker' to be valid, got %v", err)
+		} else if !valid && err == nil {
+			t.Error("expected 'blocker' to be invalid")
+		}
+
+		if err = mgr.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package check
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+// MessageEnv is the data available to a templated `message` or
+// `description`.
+//
+// A rule opts in to templating with `template: true` -- otherwise, its
+// messages use `%s`-style substitution (and any `{{` is left alone). For
+// example,
+//
+//	template: true
+//	message: "Use '{{index .Suggestions 0}}' instead of '{{.Match | lower}}'."
+type MessageEnv struct {
+	Match       string     // the matched text
+	Groups      []string   // the match, followed by its captured groups
+	Suggestions []string   // the replacement text of the alert's suggestions
+	Rule        Definition // the rule that created the alert
+	Path        string     // the path of the file being linted
+}
+
+var messageFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"title": strings.Title, //nolint:staticcheck
+	"trim":  strings.TrimSpace,
+	"join":  func(sep string, s []string) string { return strings.Join(s, sep) },
+	"quote": func(s string) string { return "'" + s + "'" },
+}
+
+// templates caches the parsed form of each templated message.
+var templates sync.Map
+
+func parseMessage(msg string) (*template.Template, error) {
+	if tmpl, ok := templates.Load(msg); ok {
+		return tmpl.(*template.Template), nil
+	}
+
+	tmpl, err := template.New("message").Funcs(messageFuncs).Parse(msg)
+	if err != nil {
+		return nil, err
+	}
+	templates.Store(msg, tmpl)
+
+	return tmpl, nil
+}
+
+// checkMessages ensures that the messages in `def` are valid (if they're
+// templated at all).
+func checkMessages(def Definition, path string) error {
+	for key, msg := range map[string]string{"message": def.Message, "description": def.Description} {
+		if err := checkMessage(def.Template, key, msg, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMessage ensures that `msg`, the value of `key` in the file at `path`,
+// is a valid template (if `template` is set).
+func checkMessage(template bool, key, msg, path string) error {
+	if !template {
+		return nil
+	} else if _, err := parseMessage(msg); err != nil {
+		return core.NewE201FromTarget(err.Error(), key, path)
+	}
+	return nil
+}
+
+func renderMessage(msg string, env MessageEnv) (string, error) {
+	tmpl, err := parseMessage(msg)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, env); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// RenderMessages executes the templated message and description (if any) of
+// the rule that created `a`, using their translations into `lang` if there
+// are any (see `Localize`).
+//
+// NOTE: Whether a message is templated is decided by the rule (`template`),
+// not by the alert: once its `%s` values have been filled in, an alert's
+// message may contain text from the document (e.g., Hugo's `{{ .Foo }}`).
+//
+// A template that fails to render for `a` (e.g., `{{index .Suggestions 0}}`
+// for an alert without suggestions) is left as is, so that one alert can't
+// stop the rest of the run; its error is returned.
+func RenderMessages(a *core.Alert, def Definition, lang, path string) error {
+	if !def.Template {
+		return nil
+	}
+
+	msg, desc := def.Message, def.Description
+	if t, ok := translation(def.Translations, lang); ok {
+		if t.Message != "" {
+			msg = t.Message
+		}
+		if t.Description != "" {
+			desc = t.Description
+		}
+	}
+
+	env := MessageEnv{Match: a.Match, Groups: a.Groups, Rule: def, Path: path}
+	if len(env.Groups) == 0 {
+		env.Groups = []string{a.Match}
+	}
+	for _, s := range a.Suggestions {
+		env.Suggestions = append(env.Suggestions, s.NewText)
+	}
+
+	var failed error
+	if rendered, err := renderMessage(msg, env); err != nil {
+		failed = core.NewE100(def.Name+".message", err)
+	} else {
+		a.Message = rendered
+	}
+
+	if rendered, err := renderMessage(desc, env); err != nil && failed == nil {
+		failed = core.NewE100(def.Name+".description", err)
+	} else if err == nil {
+		a.Description = rendered
+	}
+
+	return failed
+}
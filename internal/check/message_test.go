@@ -0,0 +1,84 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+func TestRenderMessages(t *testing.T) {
+	def := Definition{Name: "Test.Rule", Level: "error", Template: true}
+	cases := []struct {
+		msg      string
+		alert    core.Alert
+		expected string
+	}{
+		{
+			msg:      "Avoid '{{.Match | lower}}' ({{.Rule.Level}}).",
+			alert:    core.Alert{Match: "FOO"},
+			expected: "Avoid 'foo' (error).",
+		},
+		{
+			msg:      "'{{index .Groups 2}}' follows '{{index .Groups 1}}'.",
+			alert:    core.Alert{Match: "a b", Groups: []string{"a b", "a", "b"}},
+			expected: "'b' follows 'a'.",
+		},
+		{
+			msg: "Use {{.Suggestions | join \" or \"}}.",
+			alert: core.Alert{Match: "js", Suggestions: []core.Edit{
+				{NewText: "JavaScript"}, {NewText: "JS"}}},
+			expected: "Use JavaScript or JS.",
+		},
+		{
+			msg:      "{{.Path}}: '{{index .Groups 0}}'",
+			alert:    core.Alert{Match: "foo"},
+			expected: "test.md: 'foo'",
+		},
+	}
+
+	for _, c := range cases {
+		a := c.alert
+		a.Message = c.msg
+		def.Message = c.msg
+		if err := RenderMessages(&a, def, "en", "test.md"); err != nil {
+			t.Fatal(err)
+		} else if a.Message != c.expected {
+			t.Errorf("expected = %q, got = %q", c.expected, a.Message)
+		}
+	}
+
+	// A template that fails to render is left as is.
+	def.Message, def.Description = "{{index .Suggestions 0}}", "About {{.Match}}."
+	a := core.Alert{Match: "foo", Message: def.Message}
+	if err := RenderMessages(&a, def, "en", "test.md"); err == nil {
+		t.Error("expected an error for an out-of-range suggestion")
+	} else if a.Message != def.Message || a.Description != "About foo." {
+		t.Errorf("unexpected alert: %+v", a)
+	}
+}
+
+func TestUntemplatedMessages(t *testing.T) {
+	// Without `template: true`, messages are never templates -- even if
+	// they (or the text substituted into them) contain `{{`.
+	def := Definition{Name: "Test.Rule", Message: "Use '{{< ref >}}', not '%s'."}
+	if err := checkMessages(def, "Rule.yml"); err != nil {
+		t.Fatal(err)
+	}
+
+	a := core.Alert{Match: "{{ .Foo }}"}
+	setMessages(&a, def, a.Match)
+	if err := RenderMessages(&a, def, "en", "test.md"); err != nil {
+		t.Fatal(err)
+	} else if a.Message != "Use '{{< ref >}}', not '{{ .Foo }}'." {
+		t.Errorf("expected the message to be left alone, got = %q", a.Message)
+	}
+}
+
+func TestCheckMessages(t *testing.T) {
+	if err := checkMessages(Definition{Message: "{{.Match", Template: true}, "Rule.yml"); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+	if err := checkMessages(Definition{Description: "{{.Match}}", Template: true}, "Rule.yml"); err != nil {
+		t.Error(err)
+	}
+}
@@ -21,6 +21,7 @@ var headings = regexp.MustCompile(`heading\.(h[1-6])`)
 // Metric implements arbitrary, readability-like formulas.
 type Metric struct {
 	Definition `mapstructure:",squash"`
+	Limits     `mapstructure:",squash"`
 	// `metric` (`string`): the formula to be dynamically evaluated.
 	//
 	// Variables: # of words, # of sentences, etc.
@@ -39,19 +40,40 @@ func NewMetric(_ *core.Config, generic baseCheck, path string) (Metric, error) {
 		return rule, readStructureError(err, path)
 	}
 
+	if err = checkLimits(&rule.Limits, path); err != nil {
+		return rule, err
+	}
+
 	rule.path = path
-	rule.Definition.Scope = []string{"summary"}
+	rule.Definition.Scope = passageScope(rule.Scope)
 	rule.Formula = headings.ReplaceAllString(rule.Formula, "heading_$1")
 
 	return rule, nil
 }
 
 // Run calculates the readability level of the given text.
-func (o Metric) Run(_ nlp.Block, f *core.File) ([]core.Alert, error) {
+//
+// Its message receives the result of the formula and the line range of the
+// text that was scored.
+func (o Metric) Run(blk nlp.Block, f *core.File) ([]core.Alert, error) {
 	alerts := []core.Alert{}
-	ctx := context.Background()
 
-	parameters, err := f.ComputeMetrics()
+	p, ok, err := newPassage(o.Definition, blk, f)
+	if !ok || err != nil {
+		return alerts, err
+	}
+
+	var parameters map[string]interface{}
+	switch o.Scope[0] {
+	case "section":
+		// The markup counts (e.g., `list`) are those of the section itself.
+		section, _ := f.SectionAt(blk.Line + 1)
+		parameters, err = core.TextMetrics(section.Metrics, p.text)
+	case "paragraph":
+		parameters, err = core.TextMetrics(nil, p.text)
+	default:
+		parameters, err = f.ComputeMetrics()
+	}
 	if err != nil {
 		return alerts, err
 	}
@@ -64,10 +86,14 @@ func (o Metric) Run(_ nlp.Block, f *core.File) ([]core.Alert, error) {
 		}
 	}
 
+	// The formula and condition share our time limit.
+	ctx, cancel := o.context()
+	defer cancel()
+
 	// The actual result of our formula.
 	//
 	// We need this to allow showing the result in a rule's message.
-	res, err := evalMath(ctx, o.Formula, parameters)
+	res, err := evalMath(ctx, o.Formula, parameters, o.Limits)
 	if err != nil {
 		return alerts, core.NewE201FromTarget(err.Error(), "formula", o.path)
 	}
@@ -75,16 +101,14 @@ func (o Metric) Run(_ nlp.Block, f *core.File) ([]core.Alert, error) {
 	// The binary result of our formula:
 	eqb := fmt.Sprintf("%f %s", res, o.Condition)
 
-	match, err := evalMath(ctx, eqb, parameters)
+	match, err := evalMath(ctx, eqb, parameters, o.Limits)
 	if err != nil {
 		return alerts, core.NewE201FromTarget(err.Error(), "condition", o.path)
 	}
 
 	if match.(bool) {
-		a := core.Alert{Check: o.Name, Severity: o.Level, Span: []int{1, 1},
-			Link: o.Link}
-		a.Message, a.Description = formatMessages(
-			o.Message, o.Description, fmt.Sprintf("%.2f", res))
+		a := p.alert
+		setMessages(&a, o.Definition, fmt.Sprintf("%.2f", res), p.lines)
 		alerts = append(alerts, a)
 	}
 
@@ -105,6 +129,7 @@ func evalMath(
 	ctx context.Context,
 	expr string,
 	params map[string]interface{},
+	limits Limits,
 ) (interface{}, error) {
 	expr = strings.TrimSpace(expr)
 	if expr == "" {
@@ -113,6 +138,7 @@ func evalMath(
 
 	script := tengo.NewScript([]byte(fmt.Sprintf(boilerplate, expr)))
 	script.SetImports(stdlib.GetModuleMap("math"))
+	script.SetMaxAllocs(int64(limits.MaxAllocs))
 
 	for pk, pv := range params {
 		err := script.Add(pk, pv)
@@ -123,7 +149,7 @@ func evalMath(
 
 	compiled, err := script.RunContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("script run: %w", err)
+		return nil, fmt.Errorf("script run: %w", limits.explain(err))
 	}
 
 	return compiled.Get("__res__").Value(), nil
@@ -0,0 +1,114 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+// passageScope returns the scope of a rule that scores whole passages of
+// text (`readability` and `metric`): `paragraph` or `section` (the content
+// under a heading) if requested and `summary` (the whole document) otherwise.
+func passageScope(scope []string) []string {
+	if len(scope) == 1 && core.StringInSlice(scope[0], []string{"paragraph", "section"}) {
+		return scope
+	}
+	return []string{"summary"}
+}
+
+// A passage is the text that a `readability` or `metric` rule scores.
+type passage struct {
+	text  string
+	lines string // the passage's line range -- e.g., "12-30"
+	alert core.Alert
+}
+
+// newPassage finds the text of `blk` to score along with an alert (without a
+// message) that marks its start, or false if there's nothing to score.
+//
+// For a `section` block, the alert marks its heading; otherwise, it marks the
+// first word of its content. Document-level (`summary`) alerts are reported
+// on the first line, as they've always been.
+func newPassage(def Definition, blk nlp.Block, f *core.File) (passage, bool, error) {
+	p := passage{text: blk.Text, lines: passageLines(blk, f)}
+	if def.Scope[0] == "summary" {
+		p.alert = core.Alert{Check: def.Name, Severity: def.Level,
+			Span: []int{1, 1}, Link: def.Link}
+		return p, true, nil
+	}
+
+	offset := 0
+	if def.Scope[0] == "section" {
+		heading, body, _ := strings.Cut(blk.Text, "\n\n")
+		if strings.IndexFunc(body, isWordRune) < 0 {
+			return p, false, nil
+		}
+		p.text = body
+
+		if heading != "" {
+			a, err := makeAlert(def, []int{0, utf8.RuneCountInString(heading)}, blk.Text)
+			p.alert = a
+			return p, true, err
+		}
+		offset = 2
+	} else {
+		// `metric` expects each paragraph to be followed by a blank line.
+		p.text = strings.TrimSpace(p.text) + "\n\n"
+	}
+
+	// The first word of the passage.
+	start := strings.IndexFunc(blk.Text[offset:], isWordRune)
+	if start < 0 {
+		return p, false, nil
+	}
+	start += offset
+
+	end := strings.IndexFunc(blk.Text[start:], func(r rune) bool { return !isWordRune(r) })
+	if end < 0 {
+		end = len(blk.Text)
+	} else {
+		end += start
+	}
+
+	loc := []int{
+		utf8.RuneCountInString(blk.Text[:start]),
+		utf8.RuneCountInString(blk.Text[:end])}
+
+	a, err := makeAlert(def, loc, blk.Text)
+	p.alert = a
+
+	return p, true, err
+}
+
+// passageLines returns the range of lines covered by `blk`.
+//
+// NOTE: A block's text can end with whitespace (e.g., the newline after a
+// paragraph of plain text), which doesn't count towards its last line.
+func passageLines(blk nlp.Block, f *core.File) string {
+	if len(blk.Segments) == 0 {
+		return fmt.Sprintf("1-%d", len(f.Lines))
+	}
+
+	m := f.SourceMap()
+	start, _ := m.Position(blk.Segments[0].Source)
+
+	end := start
+	for i := len(blk.Segments) - 1; i >= 0; i-- {
+		seg := blk.Segments[i]
+		if seg.Start < 0 || seg.Start+seg.Size > len(blk.Text) {
+			continue
+		}
+
+		text := strings.TrimRightFunc(blk.Text[seg.Start:seg.Start+seg.Size], unicode.IsSpace)
+		if text != "" {
+			end, _ = m.Position(seg.Source + len(text) - 1)
+			break
+		}
+	}
+
+	return fmt.Sprintf("%d-%d", start, end)
+}
@@ -0,0 +1,41 @@
+package check
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+func TestPassageScope(t *testing.T) {
+	cases := []struct {
+		scope    []string
+		expected []string
+	}{
+		{[]string{"text"}, []string{"summary"}},
+		{[]string{"section"}, []string{"section"}},
+		{[]string{"paragraph"}, []string{"paragraph"}},
+		{[]string{"paragraph", "section"}, []string{"summary"}},
+	}
+
+	for _, c := range cases {
+		if got := passageScope(c.scope); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("passageScope(%v) = %v, expected %v", c.scope, got, c.expected)
+		}
+	}
+}
+
+func TestPassageLines(t *testing.T) {
+	src := "First.\n\nA one-line paragraph.\n\n"
+	f := &core.File{Lines: strings.SplitAfter(src, "\n")}
+
+	text := "A one-line paragraph.\n\n"
+	blk := nlp.NewBlock("", text, "paragraph")
+	blk.Segments = []nlp.Segment{{Start: 0, Source: 8, Size: len(text)}}
+
+	if got := passageLines(blk, f); got != "3-3" {
+		t.Errorf("expected lines 3-3, got %s", got)
+	}
+}
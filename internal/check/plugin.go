@@ -0,0 +1,341 @@
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// pluginPages is the most memory (in 64 KiB pages) that a plugin may use.
+const pluginPages = 1024
+
+// pluginInstances is the most (idle) instances of a plugin's module that we
+// keep around for reuse.
+var pluginInstances = runtime.NumCPU()
+
+// Plugin runs a WebAssembly module.
+//
+// The module is run in a sandbox (with WASI available, but no access to the
+// file system, network, or environment) and must export:
+//
+//   - `memory`;
+//   - `alloc(size i32) i32`, which returns a pointer to `size` bytes that we
+//     can write to; and
+//   - a function (`check`, by default) that takes the block's text and a
+//     JSON object of metadata (`scope`, `path`, `format`, `ext`, and
+//     `lang`), each as a pointer and a length, and returns a pointer (in its
+//     upper 32 bits) and length (in its lower 32 bits) of a JSON array of
+//     alerts: `[{"begin": 0, "end": 4}]`.
+//
+// Each alert gives the byte offsets of its match in the text, along with an
+// optional `message` (which replaces the rule's) and `severity`. If the
+// module also exports `dealloc(ptr i32, size i32)`, we call it to free each
+// buffer once we're done with it.
+//
+// A rule holds on to its module's runtime, which must be released with
+// `Close`.
+type Plugin struct {
+	Definition `mapstructure:",squash"`
+	// `module` (`string`): The path to the `.wasm` file, relative to the
+	// rule's directory (or `StylesPath`).
+	Module string
+	// `function` (`string`): The function to call (`check`, by default).
+	Function string
+	// `timeout` (`int`): The most time, in milliseconds, that a call may
+	// take (see `Limits`).
+	Timeout int
+
+	path      string
+	levels    map[string]int
+	runtime   wazero.Runtime
+	compiled  wazero.CompiledModule
+	instances chan api.Module
+}
+
+type pluginAlert struct {
+	Begin    int    `json:"begin"`
+	End      int    `json:"end"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+type pluginMeta struct {
+	Scope  string `json:"scope"`
+	Path   string `json:"path"`
+	Format string `json:"format"`
+	Ext    string `json:"ext"`
+	Lang   string `json:"lang"`
+}
+
+// NewPlugin creates a new `plugin`-based rule.
+func NewPlugin(cfg *core.Config, generic baseCheck, path string) (Plugin, error) {
+	rule := Plugin{}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	err = checkScopes(rule.Scope, path)
+	if err != nil {
+		return rule, err
+	}
+
+	rule.levels = cfg.LevelToInt
+
+	if rule.Module == "" {
+		return rule, core.NewE201FromTarget("'module' is required.", "extends", path)
+	} else if rule.Function == "" {
+		rule.Function = "check"
+	}
+
+	if rule.Timeout < 0 {
+		return rule, core.NewE201FromTarget("'timeout' can't be negative.", "timeout", path)
+	} else if rule.Timeout == 0 {
+		rule.Timeout = defaultTimeout
+	}
+
+	module := filepath.Join(filepath.Dir(path), rule.Module)
+	if !core.FileExists(module) {
+		module = core.FindAsset(cfg, rule.Module)
+	}
+
+	wasm, err := os.ReadFile(module)
+	if err != nil {
+		return rule, core.NewE201FromTarget(
+			fmt.Sprintf("unable to read '%s'.", rule.Module), "module", path)
+	}
+
+	ctx := context.Background()
+
+	// NOTE: `WithCloseOnContextDone` lets a call's timeout interrupt the
+	// module (e.g., in an infinite loop).
+	rule.runtime = wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(pluginPages).
+		WithCloseOnContextDone(true))
+
+	err = rule.load(ctx, wasm, path)
+	if err != nil {
+		rule.runtime.Close(ctx)
+		return rule, err
+	}
+
+	// NOTE: Instances aren't safe for concurrent use, so each goroutine
+	// gets its own.
+	rule.instances = make(chan api.Module, pluginInstances)
+	rule.path = path
+
+	return rule, nil
+}
+
+// load compiles (and validates) the plugin's module.
+func (p *Plugin) load(ctx context.Context, wasm []byte, path string) error {
+	_, err := wasi_snapshot_preview1.Instantiate(ctx, p.runtime)
+	if err != nil {
+		return core.NewE201FromTarget(err.Error(), "module", path)
+	}
+
+	p.compiled, err = p.runtime.CompileModule(ctx, wasm)
+	if err != nil {
+		return core.NewE201FromTarget(err.Error(), "module", path)
+	}
+
+	exported := p.compiled.ExportedFunctions()
+	for _, name := range []string{"alloc", p.Function} {
+		if _, found := exported[name]; !found {
+			return core.NewE201FromTarget(
+				fmt.Sprintf("'%s' doesn't export '%s'.", p.Module, name), "module", path)
+		}
+	}
+
+	if _, found := p.compiled.ExportedMemories()["memory"]; !found {
+		return core.NewE201FromTarget(
+			fmt.Sprintf("'%s' doesn't export 'memory'.", p.Module), "module", path)
+	}
+
+	return nil
+}
+
+// Close releases the plugin's runtime, along with all of its module's
+// instances.
+func (p Plugin) Close() error {
+	return p.runtime.Close(context.Background())
+}
+
+// Run calls the plugin's function on `blk` and returns its alerts.
+func (p Plugin) Run(blk nlp.Block, f *core.File) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	meta, err := json.Marshal(pluginMeta{
+		Scope:  blk.Scope,
+		Path:   f.Path,
+		Format: f.Format,
+		Ext:    f.RealExt,
+		Lang:   f.NLP.Lang,
+	})
+	if err != nil {
+		return alerts, core.NewE100(p.Name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), time.Duration(p.Timeout)*time.Millisecond)
+	defer cancel()
+
+	mod, err := p.instance(ctx)
+	if err != nil {
+		return alerts, core.NewE201FromTarget(err.Error(), "module", p.path)
+	}
+
+	out, err := p.call(ctx, mod, []byte(blk.Text), meta)
+	if err != nil {
+		// NOTE: The instance may be left in a bad state (e.g., after a
+		// trap), so we don't reuse it.
+		mod.Close(context.Background())
+		if ctx.Err() != nil {
+			err = fmt.Errorf("exceeded the time limit of %dms (see 'timeout')", p.Timeout)
+		}
+		return alerts, core.NewE201FromTarget(err.Error(), "module", p.path)
+	}
+	p.release(mod)
+
+	found := []pluginAlert{}
+	if err = json.Unmarshal(out, &found); err != nil {
+		return alerts, core.NewE201FromTarget(
+			fmt.Sprintf("invalid alerts: %s", err), "module", p.path)
+	}
+
+	for _, pa := range found {
+		if pa.Begin < 0 || pa.End < pa.Begin || pa.End > len(blk.Text) {
+			return alerts, core.NewE201FromTarget(
+				fmt.Sprintf("invalid location: [%d, %d]", pa.Begin, pa.End), "module", p.path)
+		}
+
+		match := blk.Text[pa.Begin:pa.End]
+		loc := []int{
+			utf8.RuneCountInString(blk.Text[:pa.Begin]),
+			utf8.RuneCountInString(blk.Text[:pa.End])}
+
+		a := core.Alert{
+			Check: p.Name, Severity: p.Level, Span: loc, Link: p.Link,
+			Match: match, Action: p.Action}
+
+		if pa.Severity != "" {
+			if _, found := p.levels[pa.Severity]; !found {
+				return alerts, core.NewE201FromTarget(
+					fmt.Sprintf("invalid severity: '%s'", pa.Severity), "module", p.path)
+			}
+			a.Severity = pa.Severity
+		}
+
+		def := p.Definition
+		if pa.Message != "" {
+			def.Message = pa.Message
+		}
+
+		setMessages(&a, def, match)
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
+// instance returns an unused instance of the plugin's module.
+func (p Plugin) instance(ctx context.Context) (api.Module, error) {
+	select {
+	case mod := <-p.instances:
+		return mod, nil
+	default:
+	}
+
+	// NOTE: We clear the default start function (`_start`) since, for a WASI
+	// "command," it would run (and exit) the module.
+	config := wazero.NewModuleConfig().WithName("").WithStartFunctions("_initialize")
+	return p.runtime.InstantiateModule(ctx, p.compiled, config)
+}
+
+// release returns `mod` to our pool of instances, closing it if the pool is
+// already full.
+func (p Plugin) release(mod api.Module) {
+	select {
+	case p.instances <- mod:
+	default:
+		mod.Close(context.Background())
+	}
+}
+
+// call writes `text` and `meta` to the module's memory, calls the plugin's
+// function, and returns (a copy of) its output.
+func (p Plugin) call(ctx context.Context, mod api.Module, text, meta []byte) ([]byte, error) {
+	args := []uint64{}
+	for _, data := range [][]byte{text, meta} {
+		ptr, err := p.write(ctx, mod, data)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, uint64(ptr), uint64(len(data)))
+	}
+
+	results, err := mod.ExportedFunction(p.Function).Call(ctx, args...)
+	if err != nil {
+		return nil, err
+	} else if len(results) != 1 {
+		return nil, fmt.Errorf("'%s' must return one value", p.Function)
+	}
+
+	ptr, size := uint32(results[0]>>32), uint32(results[0])
+
+	view, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return nil, fmt.Errorf("output out of range: [%d, %d]", ptr, ptr+size)
+	}
+
+	out := make([]byte, len(view))
+	copy(out, view)
+
+	if dealloc := mod.ExportedFunction("dealloc"); dealloc != nil {
+		args = append(args, uint64(ptr), uint64(size))
+		for i := 0; i < len(args); i += 2 {
+			if _, err = dealloc.Call(ctx, args[i], args[i+1]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// write copies `data` into a buffer allocated by the module.
+func (p Plugin) write(ctx context.Context, mod api.Module, data []byte) (uint32, error) {
+	results, err := mod.ExportedFunction("alloc").Call(ctx, uint64(len(data)))
+	if err != nil {
+		return 0, err
+	}
+
+	ptr := uint32(results[0])
+	if !mod.Memory().Write(ptr, data) {
+		return 0, fmt.Errorf("input out of range: [%d, %d]", ptr, int(ptr)+len(data))
+	}
+
+	return ptr, nil
+}
+
+// Fields provides access to the internal rule definition.
+func (p Plugin) Fields() Definition {
+	return p.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (p Plugin) Pattern() string {
+	return ""
+}
@@ -0,0 +1,147 @@
+package check
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+	"github.com/tetratelabs/wazero/api"
+)
+
+func TestPlugin(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// See `testdata/styles/Checks/todo.wat`.
+	path := "../../testdata/styles/Checks/Plugin.yml"
+
+	rule, err := NewPlugin(cfg, baseCheck{
+		"name":    "Checks.Plugin",
+		"message": "Resolve '%s'.",
+		"level":   "error",
+		"module":  "todo.wasm",
+	}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rule.Close()
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for text, expected := range map[string]int{
+		"TODO: finish this.": 1,
+		"This is done.":      0,
+		"ok":                 0,
+	} {
+		// Run each twice to reuse the module's instance.
+		for i := 0; i < 2; i++ {
+			alerts, rerr := rule.Run(nlp.NewBlock("", text, "paragraph"), file)
+			if rerr != nil {
+				t.Fatal(rerr)
+			} else if len(alerts) != expected {
+				t.Fatalf("%q: expected %d alert(s), got %d", text, expected, len(alerts))
+			} else if expected == 0 {
+				continue
+			}
+
+			a := alerts[0]
+			if a.Match != "TODO" || a.Message != "Resolve 'TODO'." || a.Severity != "warning" {
+				t.Errorf("%q: unexpected alert: %+v", text, a)
+			}
+		}
+	}
+
+	_, err = NewPlugin(cfg, baseCheck{
+		"name":     "Checks.Plugin",
+		"module":   "todo.wasm",
+		"function": "lint",
+	}, path)
+	if err == nil {
+		t.Error("expected an error for a missing function")
+	}
+}
+
+func TestPluginTimeout(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewPlugin(cfg, baseCheck{
+		"name":     "Checks.Plugin",
+		"module":   "todo.wasm",
+		"function": "loop",
+		"timeout":  100,
+	}, "../../testdata/styles/Checks/Plugin.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rule.Close()
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = rule.Run(nlp.NewBlock("", "TODO: finish this.", "paragraph"), file)
+	if err == nil || !strings.Contains(err.Error(), "time limit of 100ms") {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+}
+
+func TestPluginInstances(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewPlugin(cfg, baseCheck{
+		"name":   "Checks.Plugin",
+		"module": "todo.wasm",
+	}, "../../testdata/styles/Checks/Plugin.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	mods := []api.Module{}
+	for i := 0; i < cap(rule.instances)+2; i++ {
+		mod, ierr := rule.instance(ctx)
+		if ierr != nil {
+			t.Fatal(ierr)
+		}
+		mods = append(mods, mod)
+	}
+
+	for _, mod := range mods {
+		rule.release(mod)
+	}
+
+	if len(rule.instances) != cap(rule.instances) {
+		t.Errorf("expected %d pooled instances, got %d", cap(rule.instances), len(rule.instances))
+	}
+
+	for i, mod := range mods {
+		if pooled := i < cap(rule.instances); mod.IsClosed() == pooled {
+			t.Errorf("instance %d: expected closed=%v", i, !pooled)
+		}
+	}
+
+	if err = rule.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mod := range mods {
+		if !mod.IsClosed() {
+			t.Error("expected the runtime to close every instance")
+		}
+	}
+}
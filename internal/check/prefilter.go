@@ -0,0 +1,208 @@
+package check
+
+import (
+	"regexp/syntax"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+// prefilter decides which rules can be skipped for a given block without
+// running them.
+//
+// For each rule that only reports matches of its pattern (`existence` and
+// `substitution`), we extract a set of literals at least one of which must
+// appear in any match. All of these literals are then compiled into a single
+// (case-insensitive) automaton, which lets us find every rule that can't
+// match a block with one pass over its text.
+type prefilter struct {
+	matcher *literalMatcher
+	// The indices (into `matcher.tokens`) of each rule's literals.
+	rules   map[string][]int
+	skipped atomic.Int64
+}
+
+func newPrefilter(rules map[string]Rule) *prefilter {
+	p := prefilter{rules: make(map[string][]int)}
+
+	index := map[string]int{}
+	literals := []string{}
+	for name, chk := range rules {
+		found, ok := ruleLiterals(chk)
+		if !ok {
+			continue
+		}
+
+		for _, lit := range found {
+			lit = strings.ToLower(lit)
+			if _, seen := index[lit]; !seen {
+				index[lit] = len(literals)
+				literals = append(literals, lit)
+			}
+			p.rules[name] = append(p.rules[name], index[lit])
+		}
+	}
+
+	if len(literals) > 0 {
+		p.matcher = buildLiteralMatcher(literals, "", false, true, false)
+	}
+
+	return &p
+}
+
+// skips returns a function that reports whether the given rule can be
+// skipped for `blk`.
+//
+// We only scan the block's text if it's needed (i.e., if at least one of the
+// rules we're asked about has literals).
+func (p *prefilter) skips(blk nlp.Block) func(name string) bool {
+	var found []bool
+	return func(name string) bool {
+		indices, ok := p.rules[name]
+		if !ok || p.matcher == nil {
+			return false
+		} else if found == nil {
+			found = p.matcher.contains(blk.Text)
+		}
+
+		for _, i := range indices {
+			if found[i] {
+				return false
+			}
+		}
+
+		p.skipped.Add(1)
+		return true
+	}
+}
+
+// ruleLiterals returns the literals that `chk`'s matches must contain, if
+// there are any.
+func ruleLiterals(chk Rule) ([]string, bool) {
+	switch r := chk.(type) {
+	case Existence:
+		return patternLiterals(r.pattern)
+	case Substitution:
+		return patternLiterals(r.pattern)
+	}
+	return nil, false
+}
+
+func patternLiterals(m matcher) ([]string, bool) {
+	if m == nil {
+		return nil, false
+	} else if lm, ok := m.(*literalMatcher); ok {
+		return lm.tokens, true
+	}
+
+	// NOTE: Our patterns are `regexp2` patterns, so this will fail on any
+	// syntax that Go's `regexp` doesn't support (e.g., lookarounds) -- in
+	// which case the rule always runs. The same goes for syntax that both
+	// support but read differently (see `divergent`), since we'd otherwise
+	// require literals that `regexp2` doesn't.
+	pattern := m.String()
+	if divergent(pattern) {
+		return nil, false
+	}
+
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, false
+	}
+
+	return requiredLiterals(re)
+}
+
+// divergent reports whether `pattern` uses syntax that `regexp2` and Go's
+// `regexp/syntax` both accept but read differently:
+//
+//   - .NET-style character class subtraction (`[a-z-[aeiou]]`), which Go
+//     reads as a class followed by the literal `]`; and
+//   - `{,n}`, which some engines read as a quantifier.
+//
+// It errs on the side of caution: e.g., an escaped `\-[` within a class
+// also counts.
+func divergent(pattern string) bool {
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\':
+			i++
+		case inClass && c == '[' && strings.HasPrefix(pattern[i:], "[:"):
+			// A POSIX class (e.g., `[:alpha:]`), which both engines support.
+			if end := strings.Index(pattern[i+2:], ":]"); end >= 0 {
+				i += end + 3
+			}
+		case inClass && c == '[' && pattern[i-1] == '-':
+			return true
+		case inClass && c == ']':
+			inClass = false
+		case c == '[':
+			inClass = true
+			// A leading `]` (after an optional `^`) is a member of the class.
+			if strings.HasPrefix(pattern[i+1:], "^") {
+				i++
+			}
+			if strings.HasPrefix(pattern[i+1:], "]") {
+				i++
+			}
+		case c == '{' && strings.HasPrefix(pattern[i+1:], ","):
+			return true
+		}
+	}
+	return false
+}
+
+// requiredLiterals returns a set of literals, one of which must appear in
+// any match of `re`.
+func requiredLiterals(re *syntax.Regexp) ([]string, bool) {
+	switch re.Op { //nolint:exhaustive
+	case syntax.OpLiteral:
+		lit := string(re.Rune)
+		if re.Flags&syntax.FoldCase != 0 {
+			lit = strings.ToLower(lit)
+		}
+		return []string{lit}, lit != ""
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return requiredLiterals(re.Sub[0])
+		}
+	case syntax.OpAlternate:
+		literals := []string{}
+		for _, sub := range re.Sub {
+			found, ok := requiredLiterals(sub)
+			if !ok {
+				return nil, false
+			}
+			literals = append(literals, found...)
+		}
+		return literals, true
+	case syntax.OpConcat:
+		// Every part of a concatenation must match, so we can use whichever
+		// one gives us the most selective literals.
+		var best []string
+		for _, sub := range re.Sub {
+			found, ok := requiredLiterals(sub)
+			if ok && (best == nil || shortest(found) > shortest(best)) {
+				best = found
+			}
+		}
+		return best, best != nil
+	}
+	return nil, false
+}
+
+func shortest(literals []string) int {
+	size := -1
+	for _, lit := range literals {
+		if n := utf8.RuneCountInString(lit); size < 0 || n < size {
+			size = n
+		}
+	}
+	return size
+}
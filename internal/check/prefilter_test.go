@@ -0,0 +1,137 @@
+package check
+
+import (
+	"reflect"
+	"regexp/syntax"
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+func TestRequiredLiterals(t *testing.T) {
+	cases := map[string][]string{
+		`(?i)(?m)\b(?:foo|bar)\b`:          {"foo", "bar"},
+		`(?m)\b(?:e\.g\.|i\.e\.)\b`:        {"e.g.", "i.e."},
+		`\d+ percent`:                      {" percent"},
+		`(?:very|really)+ [a-z]+ly`:        {"very", "really"},
+		`(?m)\b(?:(utilize)|(leverage))\b`: {"utilize", "leverage"},
+		`(?:foo)?bar`:                      {"bar"},
+		`[A-Z]{2,}`:                        nil,
+		`(?:foo|\w+)`:                      nil,
+		`foo*`:                             {"fo"},
+	}
+
+	for regex, expected := range cases {
+		re, err := syntax.Parse(regex, syntax.Perl)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		observed, _ := requiredLiterals(re)
+		if !reflect.DeepEqual(expected, observed) {
+			t.Errorf("%s: expected %v, got %v", regex, expected, observed)
+		}
+	}
+}
+
+func TestPrefilter(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	literal, err := NewExistence(cfg, baseCheck{
+		"name": "Test.Literal", "tokens": []string{"utilize"}, "ignorecase": true}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	regex, err := NewExistence(cfg, baseCheck{
+		"name": "Test.Regex", "tokens": []string{`(?:very|really) \w+`}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookaround, err := NewExistence(cfg, baseCheck{
+		"name": "Test.Lookaround", "tokens": []string{`foo(?=bar)`}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := newPrefilter(map[string]Rule{
+		"Test.Literal":    literal,
+		"Test.Regex":      regex,
+		"Test.Lookaround": lookaround,
+	})
+
+	skips := p.skips(nlp.NewBlock("", "We UTILIZE this.", "text"))
+	for name, expected := range map[string]bool{
+		"Test.Literal":    false,
+		"Test.Regex":      true,
+		"Test.Lookaround": false,
+		"Test.Missing":    false,
+	} {
+		if skips(name) != expected {
+			t.Errorf("%s: expected skip = %v", name, expected)
+		}
+	}
+
+	if p.skipped.Load() != 1 {
+		t.Errorf("expected one skipped invocation, got %d", p.skipped.Load())
+	}
+}
+
+func TestPrefilterAgreesWithRegexp2(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		token string
+		text  string
+	}{
+		// .NET-style class subtraction: Go reads this as `[-\[a-z]` followed
+		// by the literal `]x`.
+		{`[a-z-[aeiou]]x`, "The bx here."},
+		{`[\w-[ab]]c`, "The xc here."},
+		{`a{,2}b`, "An a{,2}b here."},
+		{`[[:alpha:]]+ly`, "Do it quickly."},
+		{`[]a]bc`, "The ]bc here."},
+	}
+
+	for _, c := range cases {
+		rule, rerr := NewExistence(cfg, baseCheck{
+			"name": "Test.Rule", "tokens": []string{c.token}, "nonword": true}, "")
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+
+		// The rule (which uses `regexp2`) matches the text ...
+		if len(rule.pattern.FindAllStringSubmatchIndex(c.text, -1)) == 0 {
+			t.Fatalf("%s: expected a match in %q", c.token, c.text)
+		}
+
+		// ... so it must not be skipped.
+		p := newPrefilter(map[string]Rule{"Test.Rule": rule})
+		if p.skips(nlp.NewBlock("", c.text, "text"))("Test.Rule") {
+			t.Errorf("%s: skipped a block that it matches: %q", c.token, c.text)
+		}
+	}
+}
+
+func TestDivergent(t *testing.T) {
+	for pattern, expected := range map[string]bool{
+		`[a-z-[aeiou]]`:   true,
+		`x{,3}`:           true,
+		`[a-z]-[0-9]`:     false,
+		`[[:alpha:]-]x`:   false,
+		`\[a-[b]`:         false,
+		`(?:foo|bar)\{,}`: false,
+	} {
+		if divergent(pattern) != expected {
+			t.Errorf("%s: expected %v", pattern, expected)
+		}
+	}
+}
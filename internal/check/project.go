@@ -0,0 +1,39 @@
+package check
+
+import (
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+// A ProjectRule is a rule whose alerts depend on every linted file rather
+// than just one (i.e., it has `scope: project`).
+//
+// When project-wide, its `Run` method reports every *candidate* in a file.
+// Once all files have been linted, `Select` is given the candidates from
+// every file -- in reading order (by path and then position) -- and decides
+// which of them to report.
+type ProjectRule interface {
+	Rule
+	Select(candidates []core.Alert) []bool
+}
+
+// IsProject reports whether `chk` is a project-wide rule.
+func IsProject(chk Rule) bool {
+	_, ok := chk.(ProjectRule)
+	return ok && isProject(chk.Fields())
+}
+
+func isProject(def Definition) bool {
+	return core.StringInSlice("project", def.Scope)
+}
+
+// checkProject ensures that `scope: project` is only used by the extension
+// points that support it.
+func checkProject(chk Rule, path string) error {
+	if _, ok := chk.(ProjectRule); !ok && isProject(chk.Fields()) {
+		return core.NewE201FromTarget(
+			"scope 'project' is only supported by 'consistency' and 'conditional'.",
+			"scope",
+			path)
+	}
+	return nil
+}
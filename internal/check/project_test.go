@@ -0,0 +1,87 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+// runProject collects the candidates for each of `texts` (in order) and
+// returns the matches that `rule` selects.
+func runProject(t *testing.T, rule ProjectRule, texts ...string) []string {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates := []core.Alert{}
+	for _, text := range texts {
+		file, ferr := core.NewFile("", cfg)
+		if ferr != nil {
+			t.Fatal(ferr)
+		}
+
+		alerts, rerr := rule.Run(nlp.NewBlock("", text, "text"), file)
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		candidates = append(candidates, alerts...)
+	}
+
+	selected := []string{}
+	for i, keep := range rule.Select(candidates) {
+		if keep {
+			selected = append(selected, candidates[i].Match)
+		}
+	}
+
+	return selected
+}
+
+func TestProjectConsistency(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewConsistency(cfg, baseCheck{
+		"name":   "Test.Email",
+		"scope":  []string{"project"},
+		"either": map[string]string{"e-mail": "email"},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	} else if !IsProject(rule) {
+		t.Fatal("expected a project-wide rule")
+	}
+
+	selected := runProject(t, rule, "Send an e-mail.", "Send an email, not an e-mail.")
+	if len(selected) != 1 || selected[0] != "email" {
+		t.Errorf("expected ['email'], got %v", selected)
+	}
+}
+
+func TestProjectConditional(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewConditional(cfg, baseCheck{
+		"name":   "Test.Abbr",
+		"scope":  []string{"project"},
+		"first":  `\b([A-Z]{3,5})\b`,
+		"second": `(?:\b[A-Z][a-z]+ )+\(([A-Z]{3,5})\)`,
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	selected := runProject(t, rule,
+		"Ask NASA or the World Health Organization (WHO).",
+		"Ask WHO about NASA.")
+	if len(selected) != 2 || selected[0] != "NASA" || selected[1] != "NASA" {
+		t.Errorf("expected ['NASA', 'NASA'], got %v", selected)
+	}
+}
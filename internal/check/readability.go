@@ -28,23 +28,32 @@ func NewReadability(_ *core.Config, generic baseCheck, path string) (Readability
 	}
 
 	if core.AllStringsInSlice(rule.Metrics, readabilityMetrics) {
-		// NOTE: This is the only extension point that doesn't support scoping.
-		// The reason for this is that we need to split on sentences to
-		// calculate readability, which means that specifying a scope smaller
-		// than a paragraph or including non-block level content (i.e.,
-		// headings, list items or table cells) doesn't make sense.
-		rule.Definition.Scope = []string{"summary"}
+		// NOTE: This extension point only supports scoping by paragraph or
+		// section (defaulting to the whole document). The reason for this is
+		// that we need to split on sentences to calculate readability, which
+		// means that specifying a scope smaller than a paragraph or including
+		// non-block level content (i.e., headings, list items or table cells)
+		// doesn't make sense.
+		rule.Definition.Scope = passageScope(rule.Scope)
 	}
 
 	return rule, nil
 }
 
 // Run calculates the readability level of the given text.
-func (o Readability) Run(blk nlp.Block, _ *core.File) ([]core.Alert, error) {
+//
+// Its message receives the grade and the line range of the text that was
+// scored.
+func (o Readability) Run(blk nlp.Block, f *core.File) ([]core.Alert, error) {
 	var grade float64
 	var alerts []core.Alert
 
-	doc := summarize.NewDocument(blk.Text)
+	p, ok, err := newPassage(o.Definition, blk, f)
+	if !ok || err != nil {
+		return alerts, err
+	}
+
+	doc := summarize.NewDocument(p.text)
 
 	if core.StringInSlice("SMOG", o.Metrics) {
 		grade += doc.SMOG()
@@ -64,10 +73,8 @@ func (o Readability) Run(blk nlp.Block, _ *core.File) ([]core.Alert, error) {
 
 	grade /= float64(len(o.Metrics))
 	if grade > o.Grade {
-		a := core.Alert{Check: o.Name, Severity: o.Level,
-			Span: []int{1, 1}, Link: o.Link}
-		a.Message, a.Description = formatMessages(o.Message, o.Description,
-			fmt.Sprintf("%.2f", grade))
+		a := p.alert
+		setMessages(&a, o.Definition, fmt.Sprintf("%.2f", grade), p.lines)
 		alerts = append(alerts, a)
 	}
 
@@ -92,8 +92,7 @@ func (o Repetition) Run(blk nlp.Block, _ *core.File) ([]core.Alert, error) {
 					return alerts, erra
 				}
 
-				a.Message, a.Description = formatMessages(o.Message,
-					o.Description, curr)
+				setMessages(&a, o.Definition, curr)
 				alerts = append(alerts, a)
 				count = 0
 			}
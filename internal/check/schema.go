@@ -0,0 +1,172 @@
+package check
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+// extensionTypes maps each extension point to the struct its YAML definition
+// is decoded into.
+var extensionTypes = map[string]interface{}{
+	"capitalization": Capitalization{},
+	"conditional":    Conditional{},
+	"consistency":    Consistency{},
+	"existence":      Existence{},
+	"occurrence":     Occurrence{},
+	"repetition":     Repetition{},
+	"substitution":   Substitution{},
+	"readability":    Readability{},
+	"spelling":       Spelling{},
+	"sequence":       Sequence{},
+	"metric":         Metric{},
+	"script":         Script{},
+	"structure":      Structure{},
+	"length":         Length{},
+	"plugin":         Plugin{},
+}
+
+// internalKeys are exported fields that are set by Vale itself and therefore
+// shouldn't be advertised to rule authors.
+var internalKeys = []string{"name", "selector", "translations"}
+
+// RuleSchema returns a JSON Schema describing the YAML fields accepted by
+// every extension point.
+//
+// The schema is derived from the rule structs themselves (using the same
+// key-naming rules as `decodeRule`), so it can't drift from what Vale
+// actually accepts. `levels` are the values allowed for `level` (see
+// `core.Config.AlertLevels`).
+func RuleSchema(levels []string) map[string]interface{} {
+	branches := []interface{}{}
+	for _, point := range extensionPoints {
+		props := structSchema(reflect.TypeOf(extensionTypes[point]))
+		for _, key := range internalKeys {
+			delete(props, key)
+		}
+		props["extends"] = map[string]interface{}{"const": point}
+		// Unlike the other strings, these may be given per language.
+		props["message"] = localizedSchema
+		props["description"] = localizedSchema
+
+		branches = append(branches, map[string]interface{}{
+			"if": map[string]interface{}{
+				"properties": map[string]interface{}{
+					"extends": map[string]interface{}{"const": point},
+				},
+			},
+			"then": map[string]interface{}{
+				"properties":           props,
+				"additionalProperties": false,
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"$schema":  "http://json-schema.org/draft-07/schema#",
+		"title":    "Vale rule",
+		"type":     "object",
+		"required": []string{"extends", "message"},
+		"properties": map[string]interface{}{
+			"extends":     map[string]interface{}{"enum": extensionPoints},
+			"level":       map[string]interface{}{"enum": levels},
+			"message":     localizedSchema,
+			"description": localizedSchema,
+		},
+		"allOf": branches,
+	}
+}
+
+// localizedSchema describes a string that may be given per language (see
+// `Translation`).
+var localizedSchema = map[string]interface{}{
+	"anyOf": []interface{}{
+		map[string]interface{}{"type": "string"},
+		map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": map[string]interface{}{"type": "string"},
+		},
+	},
+}
+
+func structSchema(t reflect.Type) map[string]interface{} {
+	props := map[string]interface{}{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, squash := fieldKey(field)
+		if squash {
+			for k, v := range structSchema(field.Type) {
+				props[k] = v
+			}
+			continue
+		}
+
+		if schema := typeSchema(field.Type); schema != nil {
+			props[name] = schema
+		}
+	}
+
+	return props
+}
+
+// fieldKey returns the YAML key for the given field and whether or not it's
+// squashed into its parent.
+func fieldKey(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("mapstructure")
+
+	parts := strings.Split(tag, ",")
+	if core.StringInSlice("squash", parts[1:]) {
+		return "", true
+	} else if parts[0] != "" {
+		return parts[0], false
+	}
+
+	return strings.ToLower(field.Name), false
+}
+
+func typeSchema(t reflect.Type) map[string]interface{} {
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Ptr:
+		// Pointers are pre-compiled values (e.g., `filters`) that are
+		// provided as strings.
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice:
+		items := typeSchema(t.Elem())
+		if items == nil {
+			return nil
+		} else if items["type"] == "string" {
+			// A single value is also accepted for string lists (e.g.,
+			// `scope: heading`).
+			return map[string]interface{}{
+				"type": []string{"array", "string"}, "items": items}
+		}
+		return map[string]interface{}{"type": "array", "items": items}
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": typeSchema(t.Elem()),
+		}
+	case reflect.Struct:
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           structSchema(t),
+			"additionalProperties": false,
+		}
+	default:
+		return nil
+	}
+}
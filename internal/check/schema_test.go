@@ -0,0 +1,190 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestRuleSchema(t *testing.T) {
+	schema := RuleSchema([]string{"suggestion", "warning", "error"})
+
+	branches, ok := schema["allOf"].([]interface{})
+	if !ok || len(branches) != len(extensionPoints) {
+		t.Fatalf("expected %d branches, got %v", len(extensionPoints), branches)
+	}
+
+	for _, b := range branches {
+		branch := b.(map[string]interface{})
+		then := branch["then"].(map[string]interface{})
+		props := then["properties"].(map[string]interface{})
+
+		point := props["extends"].(map[string]interface{})["const"]
+		for _, key := range internalKeys {
+			if _, found := props[key]; found {
+				t.Errorf("%v: unexpected internal key '%s'", point, key)
+			}
+		}
+
+		if point == "substitution" {
+			if _, found := props["swap"]; !found {
+				t.Errorf("substitution: missing 'swap'")
+			}
+			if _, found := props["tokens"]; found {
+				t.Errorf("substitution: unexpected 'tokens'")
+			}
+		}
+	}
+}
+
+func TestRuleSchemaValidates(t *testing.T) {
+	schema := RuleSchema([]string{"suggestion", "warning", "error"})
+
+	valid := []string{
+		`{"extends": "existence", "message": "Avoid '%s'.", "tokens": ["foo"]}`,
+		`{"extends": "existence", "message": {"en": "Avoid '%s'.", "de": "Vermeiden Sie '%s'."},
+		  "description": {"en": "It's vague."}, "tokens": "foo"}`,
+		`{"extends": "substitution", "message": {"en": "Use '%s'."}, "swap": {"foo": "bar"}}`,
+	}
+	for _, rule := range valid {
+		if err := validate(schema, rule); err != nil {
+			t.Errorf("%s: %v", rule, err)
+		}
+	}
+
+	invalid := []string{
+		`{"extends": "existence", "message": {"en": 1}}`,
+		`{"extends": "existence", "message": "Avoid '%s'.", "swap": {"foo": "bar"}}`,
+		`{"extends": "existence"}`,
+	}
+	for _, rule := range invalid {
+		if err := validate(schema, rule); err == nil {
+			t.Errorf("%s: expected an error", rule)
+		}
+	}
+}
+
+// validate checks the JSON document `doc` against `schema`, supporting the
+// subset of JSON Schema that our schemas use.
+func validate(schema map[string]interface{}, doc string) error {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+
+	var s, v interface{}
+	if err = json.Unmarshal(b, &s); err != nil {
+		return err
+	} else if err = json.Unmarshal([]byte(doc), &v); err != nil {
+		return err
+	}
+
+	return validateValue(s, v, "$")
+}
+
+func validateValue(schema, value interface{}, path string) error { //nolint:gocyclo
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		if schema == false {
+			return fmt.Errorf("%s: not allowed", path)
+		}
+		return nil
+	}
+
+	if typ, found := s["type"]; found && !hasType(typ, value) {
+		return fmt.Errorf("%s: expected %v", path, typ)
+	}
+	if c, found := s["const"]; found && !reflect.DeepEqual(c, value) {
+		return fmt.Errorf("%s: expected %v", path, c)
+	}
+	if enum, found := s["enum"].([]interface{}); found {
+		ok = false
+		for _, e := range enum {
+			ok = ok || reflect.DeepEqual(e, value)
+		}
+		if !ok {
+			return fmt.Errorf("%s: expected one of %v", path, enum)
+		}
+	}
+
+	if anyOf, found := s["anyOf"].([]interface{}); found {
+		ok = false
+		for _, sub := range anyOf {
+			ok = ok || validateValue(sub, value, path) == nil
+		}
+		if !ok {
+			return fmt.Errorf("%s: doesn't match any schema", path)
+		}
+	}
+	if allOf, found := s["allOf"].([]interface{}); found {
+		for _, sub := range allOf {
+			if err := validateValue(sub, value, path); err != nil {
+				return err
+			}
+		}
+	}
+	if cond, found := s["if"]; found && validateValue(cond, value, path) == nil {
+		if err := validateValue(s["then"], value, path); err != nil {
+			return err
+		}
+	}
+
+	if obj, isObj := value.(map[string]interface{}); isObj {
+		required, _ := s["required"].([]interface{})
+		for _, key := range required {
+			if _, found := obj[key.(string)]; !found {
+				return fmt.Errorf("%s: missing '%v'", path, key)
+			}
+		}
+
+		props, _ := s["properties"].(map[string]interface{})
+		for key, v := range obj {
+			sub, found := props[key]
+			if !found {
+				sub, found = s["additionalProperties"]
+			}
+			if found {
+				if err := validateValue(sub, v, path+"."+key); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if arr, isArr := value.([]interface{}); isArr {
+		for i, v := range arr {
+			if err := validateValue(s["items"], v, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasType(typ, value interface{}) bool {
+	if types, ok := typ.([]interface{}); ok {
+		for _, t := range types {
+			if hasType(t, value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch v := value.(type) {
+	case string:
+		return typ == "string"
+	case bool:
+		return typ == "boolean"
+	case float64:
+		return typ == "number" || (typ == "integer" && v == math.Trunc(v))
+	case []interface{}:
+		return typ == "array"
+	case map[string]interface{}:
+		return typ == "object"
+	}
+	return typ == "null"
+}
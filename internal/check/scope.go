@@ -29,6 +29,7 @@ var allowedScopes = []string{
 	"blockquote",
 	"summary",
 	"raw",
+	"project",
 }
 
 // A Selector represents a named section of text.
@@ -60,6 +61,11 @@ func NewSelector(value []string) Selector {
 func NewScope(value []string) Scope {
 	scope := map[string][]Selector{}
 	for _, v := range value {
+		if v == "project" {
+			// Project-wide rules (see `ProjectRule`) read the same blocks as
+			// `text`-scoped ones.
+			v = "text"
+		}
 		selectors := []Selector{}
 		for _, part := range strings.Split(v, "&") {
 			selectors = append(selectors, NewSelector(strings.Split(part, ".")))
@@ -88,7 +94,7 @@ func (s Scope) partMatches(target, parent Selector, options []Selector) bool {
 		tm := target.Contains(part)
 		pm := parent.Contains(part)
 		if part.Negated && !pm {
-			if target.Has("raw") || target.Has("summary") {
+			if target.Has("raw") || target.Has("summary") || target.Has("outline") || target.Has("section") {
 				// This can't apply to sized scopes.
 				return false
 			}
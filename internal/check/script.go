@@ -1,86 +1,235 @@
 package check
 
 import (
+	"fmt"
+
 	"github.com/d5/tengo/v2"
 	"github.com/d5/tengo/v2/stdlib"
 	"github.com/errata-ai/vale/v2/internal/core"
 	"github.com/errata-ai/vale/v2/internal/nlp"
+	"github.com/jdkato/regexp"
 )
 
 // Script is Tango-based script.
 //
 // see https://github.com/d5/tengo.
+//
+// Along with `scope` (the block's text), a script has access to `vale`, a
+// map of:
+//
+//   - `scope_name`: the block's scope -- e.g., `sentence.md`;
+//   - `file`: a map of the file's `path`, `format`, `ext`, and `lang`;
+//   - `sentences`: the block's sentences; and
+//   - `tokens`: the block's words, each a map of its `text` and (part-of-
+//     speech) `tag`.
+//
+// NOTE: `vale` is only defined for scripts that use it (and don't declare
+// their own), so that it can't clash with an existing script's variables.
+//
+// It reports its matches in an array (`matches`) of maps with the `begin`
+// and `end` of each, along with an optional `message` (which replaces the
+// rule's), `severity`, and `action` (a map of its `name` and `params`).
 type Script struct {
 	Definition `mapstructure:",squash"`
+	Limits     `mapstructure:",squash"`
 	Script     string
 
-	path string
+	path     string
+	levels   map[string]int
+	compiled *tengo.Compiled
+
+	// We only define `vale` -- and compute the block's sentences and tokens
+	// (which requires tagging) -- if the script uses them.
+	env       bool
+	sentences bool
+	tokens    bool
 }
 
+var scriptEnvRE = regexp.MustCompile(`\bvale\.(\w+)`)
+var scriptDeclRE = regexp.MustCompile(`\bvale\s*:=`)
+
 // NewScript creates a new `script`-based rule.
-func NewScript(_ *core.Config, generic baseCheck, path string) (Script, error) {
+func NewScript(cfg *core.Config, generic baseCheck, path string) (Script, error) {
 	rule := Script{}
 
 	err := decodeRule(generic, &rule)
 	if err != nil {
 		return rule, readStructureError(err, path)
 	}
-
 	rule.path = path
-	return rule, nil
-}
+	rule.levels = cfg.LevelToInt
 
-// Run executes the given script and returns its Alerts.
-func (s Script) Run(blk nlp.Block, _ *core.File) ([]core.Alert, error) {
-	var alerts []core.Alert
+	if err = checkLimits(&rule.Limits, path); err != nil {
+		return rule, err
+	}
 
-	script := tengo.NewScript([]byte(s.Script))
+	script := tengo.NewScript([]byte(rule.Script))
+	script.SetMaxAllocs(int64(rule.MaxAllocs))
 	// NOTE: We don't want to enable the`os` module because of the security
 	// implications?
 	//
 	// See #495, for example.
 	script.SetImports(stdlib.GetModuleMap("text", "fmt", "math"))
 
-	err := script.Add("scope", blk.Text)
-	if err != nil {
-		return alerts, core.NewE201FromTarget(err.Error(), "script", s.path)
+	if err = script.Add("scope", ""); err != nil {
+		return rule, core.NewE201FromTarget(err.Error(), "script", path)
+	}
+
+	for _, m := range scriptEnvRE.FindAllStringSubmatch(rule.Script, -1) {
+		rule.env = true
+		rule.sentences = rule.sentences || m[1] == "sentences"
+		rule.tokens = rule.tokens || m[1] == "tokens"
 	}
 
-	compiled, err := script.Compile()
+	if rule.env && scriptDeclRE.MatchString(rule.Script) {
+		// The script has its own `vale`.
+		rule.env, rule.sentences, rule.tokens = false, false, false
+	} else if rule.env {
+		if err = script.Add("vale", map[string]interface{}{}); err != nil {
+			return rule, core.NewE201FromTarget(err.Error(), "script", path)
+		}
+	}
+
+	// NOTE: We compile the script once; each run uses its own clone (see
+	// `Run`).
+	rule.compiled, err = script.Compile()
 	if err != nil {
+		return rule, core.NewE201FromTarget(err.Error(), "script", path)
+	}
+
+	return rule, nil
+}
+
+// Run executes the given script and returns its Alerts.
+func (s Script) Run(blk nlp.Block, f *core.File) ([]core.Alert, error) {
+	var alerts []core.Alert
+
+	compiled := s.compiled.Clone()
+	for name, value := range s.vars(blk, f) {
+		if err := compiled.Set(name, value); err != nil {
+			return alerts, core.NewE201FromTarget(err.Error(), "script", s.path)
+		}
+	}
+
+	if err := s.run(compiled); err != nil {
 		return alerts, core.NewE201FromTarget(err.Error(), "script", s.path)
 	}
 
-	if err = compiled.Run(); err != nil {
+	matches, err := toMatches(compiled.Get("matches").Array(), len(blk.Text), s.levels)
+	if err != nil {
 		return alerts, core.NewE201FromTarget(err.Error(), "script", s.path)
 	}
 
-	for _, loc := range toLocation(compiled.Get("matches").Array()) {
-		match := blk.Text[loc[0]:loc[1]]
+	for _, m := range matches {
+		match := blk.Text[m.loc[0]:m.loc[1]]
 		// NOTE: We can't call `makeAlert` here because `script`-based rules
 		// don't use our custom regexp2 library, which means the offsets
 		// (`re2loc`) will be off.
 		a := core.Alert{
-			Check: s.Name, Severity: s.Level, Span: loc, Link: s.Link,
+			Check: s.Name, Severity: s.Level, Span: m.loc, Link: s.Link,
 			Match: match, Action: s.Action}
 
-		a.Message, a.Description = formatMessages(s.Message, s.Description, match)
+		if m.severity != "" {
+			a.Severity = m.severity
+		}
+		if m.action.Name != "" {
+			a.Action = m.action
+		}
+
+		def := s.Definition
+		if m.message != "" {
+			def.Message = m.message
+		}
+
+		setMessages(&a, def, match)
 		alerts = append(alerts, a)
 	}
 
 	return alerts, nil
 }
 
-func toLocation(a []interface{}) [][]int {
-	locs := [][]int{}
+// vars returns the values of the script's variables for `blk`.
+func (s Script) vars(blk nlp.Block, f *core.File) map[string]interface{} {
+	vars := map[string]interface{}{"scope": blk.Text}
+	if !s.env {
+		return vars
+	}
+
+	env := map[string]interface{}{
+		"scope_name": blk.Scope,
+		"file": map[string]interface{}{
+			"path":   f.Path,
+			"format": f.Format,
+			"ext":    f.RealExt,
+			"lang":   f.NLP.Lang,
+		},
+	}
+
+	if s.sentences {
+		sentences := []interface{}{}
+		for _, sent := range nlp.SentenceTokenizer.Tokenize(blk.Text) {
+			sentences = append(sentences, sent)
+		}
+		env["sentences"] = sentences
+	}
+
+	if s.tokens {
+		tokens := []interface{}{}
+		for _, tok := range nlp.TextToTokens(blk.Text, &f.NLP) {
+			tokens = append(tokens, map[string]interface{}{
+				"text": tok.Text,
+				"tag":  tok.Tag,
+			})
+		}
+		env["tokens"] = tokens
+	}
+
+	vars["vale"] = env
+	return vars
+}
+
+// A scriptMatch is an entry in a script's `matches`.
+type scriptMatch struct {
+	loc      []int
+	message  string
+	severity string
+	action   core.Action
+}
+
+func toMatches(a []interface{}, size int, levels map[string]int) ([]scriptMatch, error) {
+	matches := []scriptMatch{}
 	for _, i := range a {
-		m, _ := i.(map[string]interface{})
-		locs = append(locs, []int{
-			int(m["begin"].(int64)),
-			int(m["end"].(int64)),
-		})
+		m, ok := i.(map[string]interface{})
+		if !ok {
+			return matches, fmt.Errorf("invalid match: %v", i)
+		}
+
+		begin, ok1 := m["begin"].(int64)
+		end, ok2 := m["end"].(int64)
+		if !ok1 || !ok2 || begin < 0 || end < begin || int(end) > size {
+			return matches, fmt.Errorf("invalid location: [%v, %v]", m["begin"], m["end"])
+		}
+
+		match := scriptMatch{loc: []int{int(begin), int(end)}}
+		match.message, _ = m["message"].(string)
+
+		if match.severity, _ = m["severity"].(string); match.severity != "" {
+			if _, found := levels[match.severity]; !found {
+				return matches, fmt.Errorf("invalid severity: '%s'", match.severity)
+			}
+		}
+
+		if action, found := m["action"].(map[string]interface{}); found {
+			match.action.Name, _ = action["name"].(string)
+			params, _ := action["params"].([]interface{})
+			for _, p := range params {
+				match.action.Params = append(match.action.Params, fmt.Sprint(p))
+			}
+		}
+
+		matches = append(matches, match)
 	}
-	return locs
+	return matches, nil
 }
 
 // Fields provides access to the internal rule definition.
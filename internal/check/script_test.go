@@ -0,0 +1,118 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+func TestScriptEnv(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewScript(cfg, baseCheck{
+		"name":    "Test.Script",
+		"message": "Found '%s'.",
+		"level":   "error",
+		"script": `
+text := import("text")
+
+matches := []
+if vale.file.ext == ".md" && vale.scope_name == "sentence.md" && len(vale.sentences) == 2 {
+  for tok in vale.tokens {
+    if tok.tag == "NNP" {
+      start := text.index(scope, tok.text)
+      matches = append(matches, {
+        begin: start,
+        end: start + len(tok.text),
+        message: "'%s' is a name.",
+        severity: "warning",
+        action: {name: "replace", params: ["them"]}
+      })
+    }
+  }
+  matches = append(matches, {begin: 2, end: 7})
+}`,
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.RealExt = ".md"
+
+	blk := nlp.NewBlock("", "I asked Alice. She knows.", "sentence.md")
+	for i := 0; i < 2; i++ {
+		alerts, rerr := rule.Run(blk, file)
+		if rerr != nil {
+			t.Fatal(rerr)
+		} else if len(alerts) != 2 {
+			t.Fatalf("expected 2 alerts, got %d", len(alerts))
+		}
+
+		a := alerts[0]
+		if a.Match != "Alice" || a.Message != "'Alice' is a name." ||
+			a.Severity != "warning" || a.Action.Name != "replace" ||
+			len(a.Action.Params) != 1 || a.Action.Params[0] != "them" {
+			t.Errorf("unexpected alert: %+v", a)
+		}
+
+		a = alerts[1]
+		if a.Match != "asked" || a.Message != "Found 'asked'." || a.Severity != "error" {
+			t.Errorf("unexpected alert: %+v", a)
+		}
+	}
+
+	_, err = NewScript(cfg, baseCheck{
+		"name":   "Test.Script",
+		"script": `matches := [`,
+	}, "")
+	if err == nil {
+		t.Error("expected a compilation error")
+	}
+}
+
+func TestScriptOwnNames(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Scripts written before `vale` existed may use any of its names (or
+	// `vale` itself) for their own variables.
+	for _, script := range []string{
+		`text := import("text")
+tokens := text.split(scope, " ")
+file := tokens[0]
+matches := [{begin: 0, end: len(file)}]`,
+		`vale := {tokens: ["Some"]}
+matches := [{begin: 0, end: len(vale.tokens[0])}]`,
+	} {
+		rule, rerr := NewScript(cfg, baseCheck{
+			"name":    "Test.Script",
+			"message": "Found '%s'.",
+			"script":  script,
+		}, "")
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+
+		alerts, rerr := rule.Run(nlp.NewBlock("", "Some text.", "text"), file)
+		if rerr != nil {
+			t.Fatal(rerr)
+		} else if len(alerts) != 1 || alerts[0].Match != "Some" {
+			t.Errorf("unexpected alerts: %+v", alerts)
+		}
+	}
+}
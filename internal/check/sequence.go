@@ -3,27 +3,54 @@ package check
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/errata-ai/regexp2"
 	"github.com/errata-ai/vale/v2/internal/core"
 	"github.com/errata-ai/vale/v2/internal/nlp"
 	"github.com/jdkato/prose/tag"
-
-	"github.com/mitchellh/mapstructure"
 )
 
 // NLPToken represents a token of text with NLP-related attributes.
 type NLPToken struct {
-	Pattern  string
-	Tag      string
+	Pattern string
+	Tag     string
+	// `dep` (`string`): A regex for the token's grammatical relation to its
+	// head -- e.g., `nsubjpass` (see `nlp.Parse`).
+	Dep string
+	// `with` (`array`): Relations that the token's dependents must include
+	// -- e.g., `[auxpass]` for a verb in the passive voice.
+	With []string
+	// `without` (`array`): Relations that the token's dependents must not
+	// include -- e.g., `[agent]` for a passive verb without a "by" phrase.
+	Without  []string
 	Skip     int
 	re       *regexp2.Regexp
+	depRe    *regexp2.Regexp
 	Negate   bool
 	optional bool
 	start    bool
 	end      bool
 }
 
+// constrained reports whether the token places any constraints on a word.
+func (t NLPToken) constrained() bool {
+	return t.Pattern != "" || t.Tag != "" || t.relational()
+}
+
+// relational reports whether the token needs the word's dependencies.
+func (t NLPToken) relational() bool {
+	return t.Dep != "" || len(t.With) > 0 || len(t.Without) > 0
+}
+
+// A sequenceWord is a tagged word along with its relation to its head and
+// the relations of its dependents.
+type sequenceWord struct {
+	tag.Token
+	rel  string
+	deps []string
+}
+
 // Sequence looks for a user-defined sequence of tokens.
 type Sequence struct {
 	Definition   `mapstructure:",squash"`
@@ -31,13 +58,14 @@ type Sequence struct {
 	history      []int
 	Ignorecase   bool
 	needsTagging bool
+	needsParsing bool
 }
 
 // NewSequence creates a new rule from the provided `baseCheck`.
 func NewSequence(cfg *core.Config, generic baseCheck, path string) (Sequence, error) {
 	rule := Sequence{}
 
-	err := makeTokens(&rule, generic)
+	err := makeTokens(cfg, &rule, generic)
 	if err != nil {
 		return rule, readStructureError(err, path)
 	}
@@ -56,6 +84,9 @@ func NewSequence(cfg *core.Config, generic baseCheck, path string) (Sequence, er
 		if !rule.needsTagging && token.Tag != "" {
 			rule.needsTagging = true
 		}
+		if !rule.needsParsing && token.relational() {
+			rule.needsParsing = true
+		}
 
 		if token.Pattern != "" {
 			regex := makeRegexp(
@@ -72,6 +103,14 @@ func NewSequence(cfg *core.Config, generic baseCheck, path string) (Sequence, er
 			}
 			rule.Tokens[i].re = re
 		}
+
+		if token.Dep != "" {
+			re, errc := regexp2.CompileStd("^(?:" + token.Dep + ")$")
+			if errc != nil {
+				return rule, core.NewE201FromTarget(errc.Error(), "dep", path)
+			}
+			rule.Tokens[i].depRe = re
+		}
 	}
 
 	rule.Definition.Scope = []string{"sentence"}
@@ -88,10 +127,10 @@ func (s Sequence) Pattern() string {
 	return ""
 }
 
-func makeTokens(s *Sequence, generic baseCheck) error {
+func makeTokens(cfg *core.Config, s *Sequence, generic baseCheck) error {
 	for _, token := range generic["tokens"].([]interface{}) {
 		tok := NLPToken{}
-		if err := mapstructure.WeakDecode(token, &tok); err != nil {
+		if err := weakDecode(cfg, token, &tok); err != nil {
 			return err
 		}
 
@@ -104,7 +143,7 @@ func makeTokens(s *Sequence, generic baseCheck) error {
 			s.Tokens = append(s.Tokens, tok)
 		}
 
-		if tok.Pattern != "" || tok.Tag != "" {
+		if tok.constrained() {
 			tok.optional = false
 			tok.end = true
 			s.Tokens = append(s.Tokens, tok)
@@ -115,7 +154,7 @@ func makeTokens(s *Sequence, generic baseCheck) error {
 	return nil
 }
 
-func tokensMatch(token NLPToken, word tag.Token) bool {
+func tokensMatch(token NLPToken, word sequenceWord) bool {
 	failedTag, err := regexp2.MatchString(token.Tag, word.Tag)
 	if err != nil {
 		// FIXME: return the error instead ...
@@ -131,82 +170,108 @@ func tokensMatch(token NLPToken, word tag.Token) bool {
 		return false
 	}
 
+	return relationsMatch(token, word)
+}
+
+// relationsMatch reports whether `word` has the grammatical relations
+// required by `token`.
+func relationsMatch(token NLPToken, word sequenceWord) bool {
+	if token.depRe != nil && !token.depRe.MatchStringStd(word.rel) {
+		return false
+	}
+
+	for _, rel := range token.With {
+		if !core.StringInSlice(rel, word.deps) {
+			return false
+		}
+	}
+
+	for _, rel := range token.Without {
+		if core.StringInSlice(rel, word.deps) {
+			return false
+		}
+	}
+
 	return true
 }
 
-func sequenceMatches(idx int, chk Sequence, target NLPToken, words []tag.Token) ([]string, int) {
+func sequenceMatches(idx int, chk Sequence, target NLPToken, words []sequenceWord) ([]string, int) {
+	for jdx, tok := range words {
+		if tokensMatch(target, tok) && !core.IntInSlice(jdx, chk.history) {
+			return sequenceAt(idx, chk, jdx, words), jdx
+		}
+	}
+	return []string{}, 0
+}
+
+// sequenceAt returns the words of the sequence anchored (by the token at
+// `idx`) at `words[jdx]`, or nothing if it doesn't match.
+func sequenceAt(idx int, chk Sequence, jdx int, words []sequenceWord) []string {
 	var text []string
 
 	toks := chk.Tokens
 
 	sizeT := len(toks)
 	sizeW := len(words)
-	index := 0
 
-	for jdx, tok := range words {
-		if tokensMatch(target, tok) && !core.IntInSlice(jdx, chk.history) {
-			index = jdx
-			// We've found our context.
+	// We've found our context.
+	//
+	// The *first* token with a `pattern` (or, failing that, any other
+	// constraint) becomes the anchor of our search. From there, we must check both its left- and right-hand
+	// sides to ensure the sequence matches.
+	if idx > 0 {
+		// Check the left-end of the sequence:
+		//
+		// If the anchor is the first token, then there's no left-hand
+		// side to check -- hence, `idx > 0`.
+		for i := 1; idx-i >= 0; i++ {
+			if jdx-i < 0 {
+				return []string{}
+			}
+			tok := toks[idx-i]
+
+			word := words[jdx-i]
+			text = append([]string{word.Text}, text...)
+
+			mat := tokensMatch(tok, word)
+			// NOTE: We have to perform this conversion because the token slice is made
+			// with the right-hand orientation in mind. For example,
 			//
-			// The *first* token with a `pattern` becomes the anchor of our
-			// search. From there, we must check both its left- and right-hand
-			// sides to ensure the sequence matches.
-			if idx > 0 {
-				// Check the left-end of the sequence:
-				//
-				// If the anchor is the first token, then there's no left-hand
-				// side to check -- hence, `idx > 0`.
-				for i := 1; idx-i >= 0; i++ {
-					if jdx-i < 0 {
-						return []string{}, index
-					}
-					tok := toks[idx-i]
-
-					word := words[jdx-i]
-					text = append([]string{word.Text}, text...)
-
-					mat := tokensMatch(tok, word)
-					// NOTE: We have to perform this conversion because the token slice is made
-					// with the right-hand orientation in mind. For example,
-					//
-					// optional (start), optional, required (end) -> required, optional, optional
-					//
-					// (from right to left).
-					tok.optional = (tok.optional || tok.end) && !tok.start
-					if !mat && !tok.optional {
-						return []string{}, index
-					} else if mat && tok.optional {
-						break
-					}
-				}
+			// optional (start), optional, required (end) -> required, optional, optional
+			//
+			// (from right to left).
+			tok.optional = (tok.optional || tok.end) && !tok.start
+			if !mat && !tok.optional {
+				return []string{}
+			} else if mat && tok.optional {
+				break
 			}
-			if idx < sizeT {
-				// Check the right-end of the sequence
-				//
-				// If the anchor is the last token, then there's no right-hand
-				// side to check.
-				for i := 0; idx+i < sizeT; i++ {
-					if jdx+i >= sizeW {
-						return []string{}, index
-					}
-					tok := toks[idx+i]
+		}
+	}
+	if idx < sizeT {
+		// Check the right-end of the sequence
+		//
+		// If the anchor is the last token, then there's no right-hand
+		// side to check.
+		for i := 0; idx+i < sizeT; i++ {
+			if jdx+i >= sizeW {
+				return []string{}
+			}
+			tok := toks[idx+i]
 
-					word := words[jdx+i]
-					text = append(text, word.Text)
+			word := words[jdx+i]
+			text = append(text, word.Text)
 
-					mat := tokensMatch(tok, word)
-					if !mat && !tok.optional {
-						return []string{}, index
-					} else if mat && tok.optional {
-						break
-					}
-				}
+			mat := tokensMatch(tok, word)
+			if !mat && !tok.optional {
+				return []string{}
+			} else if mat && tok.optional {
+				break
 			}
-			break
 		}
 	}
 
-	return text, index
+	return text
 }
 
 func stepsToString(steps []string) string {
@@ -227,7 +292,7 @@ func (s Sequence) Run(blk nlp.Block, f *core.File) ([]core.Alert, error) {
 	var offset []string
 
 	// This is *always* sentence-scoped.
-	words := nlp.TextToTokens(blk.Text, &f.NLP)
+	words := s.words(blk.Text, f)
 
 	txt := blk.Text
 	for idx, tok := range s.Tokens {
@@ -240,15 +305,13 @@ func (s Sequence) Run(blk nlp.Block, f *core.File) ([]core.Alert, error) {
 
 				if len(steps) > 0 {
 					seq := stepsToString(steps)
-					ssp := strings.Index(txt, seq)
 
 					a := core.Alert{
 						Check: s.Name, Severity: s.Level, Link: s.Link,
-						Span: []int{ssp, ssp + len(seq)}, Hide: false,
+						Span: spanAround(txt, seq, loc[0]), Hide: false,
 						Match: seq, Action: s.Action}
 
-					a.Message, a.Description = formatMessages(s.Message,
-						s.Description, steps...)
+					setMessages(&a, s.Definition, steps...)
 					a.Offset = offset
 
 					alerts = append(alerts, a)
@@ -261,9 +324,89 @@ func (s Sequence) Run(blk nlp.Block, f *core.File) ([]core.Alert, error) {
 					offset = append(offset, converted)
 				}
 			}
-			break
+			return alerts, nil
+		}
+	}
+
+	// Without a `pattern`, we anchor our search on the first token that has
+	// any other constraint (e.g., `tag: VBN` with `with: [auxpass]`).
+	for idx, tok := range s.Tokens {
+		if !tok.Negate && tok.constrained() {
+			return s.runAnchored(idx, tok, txt, words), nil
 		}
 	}
 
 	return alerts, nil
 }
+
+// runAnchored looks for the sequence at each word that matches `target`, the
+// token at `idx`.
+func (s Sequence) runAnchored(idx int, target NLPToken, txt string, words []sequenceWord) []core.Alert {
+	alerts := []core.Alert{}
+
+	offsets := wordOffsets(txt, words)
+	for jdx, word := range words {
+		if !tokensMatch(target, word) {
+			continue
+		}
+
+		steps := sequenceAt(idx, s, jdx, words)
+		if len(steps) > 0 {
+			seq := stepsToString(steps)
+
+			a := core.Alert{
+				Check: s.Name, Severity: s.Level, Link: s.Link,
+				Span: spanAround(txt, seq, offsets[jdx]), Hide: false,
+				Match: seq, Action: s.Action}
+
+			setMessages(&a, s.Definition, steps...)
+			alerts = append(alerts, a)
+		}
+	}
+
+	return alerts
+}
+
+// words tags (and, if needed, parses) `txt`.
+func (s Sequence) words(txt string, f *core.File) []sequenceWord {
+	var tokens []tag.Token
+	var deps []nlp.Dependency
+
+	if s.needsParsing {
+		tokens, deps = nlp.TextToDependencies(txt, &f.NLP)
+	} else {
+		tokens = nlp.TextToTokens(txt, &f.NLP)
+	}
+
+	words := make([]sequenceWord, len(tokens))
+	for i, tok := range tokens {
+		words[i].Token = tok
+	}
+
+	for i, dep := range deps {
+		words[i].rel = dep.Rel
+		if dep.Head >= 0 && dep.Head < len(words) {
+			words[dep.Head].deps = append(words[dep.Head].deps, dep.Rel)
+		}
+	}
+
+	return words
+}
+
+// wordOffsets returns the (rune-based) offset of each of `words` in `txt`.
+func wordOffsets(txt string, words []sequenceWord) []int {
+	offsets := make([]int, len(words))
+
+	cursor := 0
+	for i, w := range words {
+		if j := strings.Index(txt[cursor:], w.Text); j >= 0 {
+			cursor += j
+			offsets[i] = utf8.RuneCountInString(txt[:cursor])
+			cursor += len(w.Text)
+		} else {
+			offsets[i] = utf8.RuneCountInString(txt[:cursor])
+		}
+	}
+
+	return offsets
+}
@@ -0,0 +1,105 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+func TestSequenceRelations(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewSequence(cfg, baseCheck{
+		"name":    "Test.Passive",
+		"message": "'%s' is passive.",
+		"tokens": []interface{}{
+			map[string]interface{}{
+				"tag":     "VBN|VBD",
+				"with":    []string{"auxpass"},
+				"without": []string{"agent"},
+			},
+		},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string][]string{
+		"The report was written.":                     {"written"},
+		"The report was written by the team.":         {},
+		"The team wrote the report.":                  {},
+		"Mistakes were made, but it was fixed by us.": {"made"},
+	}
+
+	for text, expected := range cases {
+		file, ferr := core.NewFile("", cfg)
+		if ferr != nil {
+			t.Fatal(ferr)
+		}
+
+		alerts, rerr := rule.Run(nlp.NewBlock("", text, "sentence"), file)
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+
+		found := []string{}
+		for _, a := range alerts {
+			found = append(found, a.Match)
+		}
+
+		if !reflect.DeepEqual(found, expected) {
+			t.Errorf("%q: expected %v, got %v", text, expected, found)
+		}
+	}
+}
+
+func TestSequenceDep(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewSequence(cfg, baseCheck{
+		"name":    "Test.Subject",
+		"message": "'%s' is a passive subject.",
+		"tokens": []interface{}{
+			map[string]interface{}{"dep": "nsubjpass|agent"},
+		},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts, err := rule.Run(nlp.NewBlock("", "The report was written by the team.", "sentence"), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := []string{}
+	for _, a := range alerts {
+		found = append(found, a.Match)
+	}
+	if !reflect.DeepEqual(found, []string{"report", "team"}) {
+		t.Errorf("expected [report team], got %v", found)
+	}
+
+	_, err = NewSequence(cfg, baseCheck{
+		"name": "Test.Subject",
+		"tokens": []interface{}{
+			map[string]interface{}{"dep": "nsubj("},
+		},
+	}, "")
+	if err == nil {
+		t.Error("expected an error for an invalid 'dep'")
+	}
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/errata-ai/vale/v2/internal/nlp"
 	"github.com/errata-ai/vale/v2/internal/spell"
 	"github.com/jdkato/regexp"
-	"github.com/mitchellh/mapstructure"
 )
 
 var defaultFilters = []*regexp.Regexp{
@@ -95,7 +94,7 @@ func NewSpelling(cfg *core.Config, generic baseCheck, path string) (Spelling, er
 		return rule, readStructureError(err, path)
 	}
 
-	err = mapstructure.WeakDecode(generic, &rule)
+	err = weakDecode(cfg, generic, &rule)
 	if err != nil {
 		return rule, readStructureError(err, path)
 	}
@@ -145,6 +144,8 @@ func (s Spelling) Run(blk nlp.Block, _ *core.File) ([]core.Alert, error) {
 	// See https://github.com/errata-ai/vale/v2/issues/148.
 	txt = s.gs.Convert(txt)
 
+	next := 0
+
 OUTER:
 	for _, word := range nlp.WordTokenizer.Tokenize(txt) {
 		for _, filter := range s.Filters {
@@ -154,14 +155,13 @@ OUTER:
 		}
 
 		if !s.gs.Spell(word) && !isMatch(s.exceptRe, word) {
-			offset := strings.Index(txt, word)
-			loc := []int{offset, offset + len(word)}
+			var loc []int
+			loc, next = findWord(txt, word, next)
 
 			a := core.Alert{Check: s.Name, Severity: s.Level, Span: loc,
 				Link: s.Link, Match: word, Action: s.Action}
 
-			a.Message, a.Description = formatMessages(s.Message,
-				s.Description, word)
+			setMessages(&a, s.Definition, word)
 
 			alerts = append(alerts, a)
 		}
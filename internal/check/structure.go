@@ -0,0 +1,151 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+// Structure checks a document's outline (i.e., its headings).
+//
+// Its message receives two arguments: the heading's text and a description
+// of the problem -- e.g., `message: "'%s' %s."`.
+type Structure struct {
+	Definition `mapstructure:",squash"`
+	// `increment` (`bool`): Report headings that skip a level (e.g., h2 ->
+	// h4).
+	Increment bool
+	// `single` (`bool`): Report any h1 after the first.
+	Single bool
+	// `depth` (`int`): The deepest heading level allowed (e.g., 3 for h3).
+	Depth int
+	// `unique` (`bool`): Report headings that repeat an earlier heading's
+	// text.
+	Unique bool
+	// `ignorecase` (`bool`): Compare heading text case-insensitively.
+	Ignorecase bool
+	// `sections` (`array`): Headings that must appear, in the given order.
+	Sections []string
+}
+
+// NewStructure creates a new `structure`-based rule.
+func NewStructure(_ *core.Config, generic baseCheck, path string) (Structure, error) {
+	rule := Structure{}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	if rule.Depth < 0 || rule.Depth > 6 {
+		return rule, core.NewE201FromTarget(
+			"'depth' must be between 1 and 6.", "depth", path)
+	}
+
+	// NOTE: A structure rule always runs on the whole outline, which we
+	// assemble after walking the document.
+	rule.Scope = []string{"outline"}
+	return rule, nil
+}
+
+// Run checks the outline of `f`, whose headings are given one per line in
+// `blk`.
+func (s Structure) Run(blk nlp.Block, f *core.File) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	lines := strings.Split(blk.Text, "\n")
+	if len(lines) != len(f.Outline) {
+		return alerts, nil
+	}
+
+	// The (rune-based) location of each heading in the block.
+	spans := make([][]int, len(lines))
+	start := 0
+	for i, line := range lines {
+		size := utf8.RuneCountInString(line)
+		spans[i] = []int{start, start + size}
+		start += size + 1
+	}
+
+	// NOTE: We combine the problems with each heading into a single alert
+	// since we only report one alert per location.
+	problems := make([][]string, len(lines))
+
+	seen := map[string]int{}
+	h1 := false
+	for i, h := range f.Outline {
+		if s.Increment && i > 0 && h.Level > f.Outline[i-1].Level+1 {
+			problems[i] = append(problems[i], fmt.Sprintf(
+				"skips from h%d to h%d", f.Outline[i-1].Level, h.Level))
+		}
+		if s.Single && h.Level == 1 {
+			if h1 {
+				problems[i] = append(problems[i], "is not the only h1")
+			}
+			h1 = true
+		}
+		if s.Depth > 0 && h.Level > s.Depth {
+			problems[i] = append(problems[i], fmt.Sprintf(
+				"is deeper than h%d", s.Depth))
+		}
+
+		key := s.normalize(h.Text)
+		if _, found := seen[key]; found && s.Unique {
+			problems[i] = append(problems[i], "is a duplicate heading")
+		} else if !found {
+			seen[key] = i
+		}
+	}
+
+	last, prev := -1, ""
+	for _, section := range s.Sections {
+		i, found := seen[s.normalize(section)]
+		if !found {
+			// We report missing sections on the first heading.
+			problems[0] = append(problems[0], fmt.Sprintf(
+				"is missing a '%s' section", section))
+			continue
+		} else if i < last {
+			problems[i] = append(problems[i], fmt.Sprintf(
+				"should come after '%s'", prev))
+		}
+		last, prev = i, section
+	}
+
+	for i, found := range problems {
+		if len(found) == 0 {
+			continue
+		}
+
+		a, err := makeAlert(s.Definition, spans[i], blk.Text)
+		if err != nil {
+			return alerts, err
+		}
+		setMessages(&a, s.Definition, a.Match, strings.Join(found, " and "))
+
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
+func (s Structure) normalize(text string) string {
+	text = strings.TrimSpace(core.WhitespaceToSpace(text))
+	if s.Ignorecase {
+		return strings.ToLower(text)
+	}
+	return text
+}
+
+// Fields provides access to the internal rule definition.
+func (s Structure) Fields() Definition {
+	return s.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (s Structure) Pattern() string {
+	return ""
+}
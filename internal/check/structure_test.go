@@ -0,0 +1,86 @@
+package check
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/internal/nlp"
+)
+
+func TestStructure(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewStructure(cfg, baseCheck{
+		"name":       "Test.Outline",
+		"message":    "'%s' %s.",
+		"increment":  true,
+		"single":     true,
+		"depth":      3,
+		"unique":     true,
+		"ignorecase": true,
+		"sections":   []string{"Prerequisites", "Procedure", "Cleanup"},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Outline = []core.Heading{
+		{Level: 1, Text: "Title"},
+		{Level: 2, Text: "Procedure"},
+		{Level: 4, Text: "Details"},
+		{Level: 2, Text: "Prerequisites"},
+		{Level: 1, Text: "Second"},
+		{Level: 2, Text: "procedure"},
+	}
+
+	lines := []string{}
+	for _, h := range file.Outline {
+		lines = append(lines, h.Text)
+	}
+
+	alerts, err := rule.Run(nlp.NewBlock("", strings.Join(lines, "\n"), "outline"), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"'Title' is missing a 'Cleanup' section.",
+		"'Procedure' should come after 'Prerequisites'.",
+		"'Details' skips from h2 to h4 and is deeper than h3.",
+		"'Second' is not the only h1.",
+		"'procedure' is a duplicate heading.",
+	}
+	if len(alerts) != len(expected) {
+		t.Fatalf("expected %d alerts, got %d", len(expected), len(alerts))
+	}
+
+	for i, a := range alerts {
+		if a.Message != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], a.Message)
+		}
+	}
+
+	if alerts[2].Span[0] != 16 || alerts[2].Span[1] != 23 {
+		t.Errorf("unexpected span for 'Details': %v", alerts[2].Span)
+	}
+}
+
+func TestStructureDepth(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewStructure(cfg, baseCheck{"name": "Test.Outline", "depth": 7}, "")
+	if err == nil {
+		t.Fatal("expected an error for 'depth: 7'")
+	}
+}
@@ -17,9 +17,13 @@ type Substitution struct {
 	POS        string
 	Swap       map[string]string
 	exceptRe   *regexp2.Regexp
-	pattern    *regexp2.Regexp
+	pattern    matcher
 	Ignorecase bool
 	Nonword    bool
+	// `inflect` (`bool`): Also match the inflected forms of each (single
+	// word) key, suggesting its replacement in the same form -- e.g.,
+	// `utilized` -> `used` for `utilize: use`.
+	Inflect bool
 }
 
 // NewSubstitution creates a new `substitution`-based rule.
@@ -43,14 +47,26 @@ func NewSubstitution(cfg *core.Config, generic baseCheck, path string) (Substitu
 	}
 	rule.exceptRe = re
 
+	word := !rule.Nonword
 	regex := makeRegexp(
 		cfg.WordTemplate,
 		rule.Ignorecase,
-		func() bool { return !rule.Nonword },
+		func() bool { return word },
 		func() string { return "" }, true)
 
+	order := []string{}
+	if rule.Inflect {
+		rule.Swap, order = inflectSwap(rule.Swap)
+	} else {
+		for regexstr := range rule.Swap {
+			order = append(order, regexstr)
+		}
+	}
+
+	keys := []string{}
 	replacements := []string{}
-	for regexstr, replacement := range rule.Swap {
+	for _, regexstr := range order {
+		replacement := rule.Swap[regexstr]
 		opens := strings.Count(regexstr, "(")
 		if opens != strings.Count(regexstr, "(?") &&
 			opens != strings.Count(regexstr, `\(`) {
@@ -70,9 +86,19 @@ func NewSubstitution(cfg *core.Config, generic baseCheck, path string) (Substitu
 				"capture group not supported; use '(?:' instead of '('", regexstr, path)
 		}
 		tokens += `(` + regexstr + `)|`
+		keys = append(keys, regexstr)
 		replacements = append(replacements, replacement)
 	}
 	regex = fmt.Sprintf(regex, strings.TrimRight(tokens, "|"))
+	rule.repl = replacements
+
+	if literalTemplate(cfg, word) {
+		lm, ok := newLiteralMatcher(keys, regex, word, rule.Ignorecase, true)
+		if ok {
+			rule.pattern = lm
+			return rule, nil
+		}
+	}
 
 	re, err = regexp2.CompileStd(regex)
 	if err != nil {
@@ -80,7 +106,6 @@ func NewSubstitution(cfg *core.Config, generic baseCheck, path string) (Substitu
 	}
 
 	rule.pattern = re
-	rule.repl = replacements
 	return rule, nil
 }
 
@@ -114,6 +139,8 @@ func (s Substitution) Run(blk nlp.Block, _ *core.File) ([]core.Alert, error) {
 
 				same := matchToken(expected, observed, s.Ignorecase)
 				if !same && !isMatch(s.exceptRe, observed) {
+					swaps := strings.Split(expected, "|")
+
 					action := s.Fields().Action
 					if action.Name == "replace" && len(action.Params) == 0 {
 						action.Params = strings.Split(expected, "|")
@@ -129,9 +156,20 @@ func (s Substitution) Run(blk nlp.Block, _ *core.File) ([]core.Alert, error) {
 						return alerts, aerr
 					}
 
-					a.Message, a.Description = formatMessages(s.Message,
-						s.Description, expected, observed)
+					setMessages(&a, s.Definition, expected, observed)
 					a.Action = action
+					a.Groups = submatches(txt, submat)
+
+					if action.Name == "replace" {
+						swaps = action.Params
+					} else if action.Name != "" {
+						// The rule's action takes precedence.
+						swaps = nil
+					}
+					for _, swap := range swaps {
+						a.Suggestions = append(a.Suggestions, core.Edit{
+							NewText: strings.Replace(converted, observed, swap, 1)})
+					}
 
 					alerts = append(alerts, a)
 				}
@@ -0,0 +1,220 @@
+package check
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"gopkg.in/yaml.v2"
+)
+
+// A Translation is a localized version of a rule's message and description.
+//
+// Translations are given either inline,
+//
+//	message:
+//	  en: "Use '%s' instead of '%s'."
+//	  de: "Verwenden Sie '%s' statt '%s'."
+//
+// or in a sidecar file next to the rule (e.g., `Rule.de.yml`) that contains
+// its own `message` and `description`. A sidecar must be named for an ISO
+// 639-1 language (optionally with a region, as in `Rule.pt-BR.yml`), so that
+// rules such as `Terms.old.yml` aren't mistaken for one.
+type Translation struct {
+	Message     string
+	Description string
+}
+
+// defaultLang is the language used for a rule's default message, if it has
+// a translation for it.
+const defaultLang = "en"
+
+// langPattern matches a language code such as `de`, `ja`, or `pt-BR`.
+var langPattern = regexp.MustCompile(`^([a-z]{2})(?:[-_][A-Za-z]{2,4})?$`)
+
+// languages are the ISO 639-1 codes.
+var languages = strings.Fields(`
+aa ab ae af ak am an ar as av ay az ba be bg bh bi bm bn bo br bs ca ce ch co
+cr cs cu cv cy da de dv dz ee el en eo es et eu fa ff fi fj fo fr fy ga gd gl
+gn gu gv ha he hi ho hr ht hu hy hz ia id ie ig ii ik io is it iu ja jv ka kg
+ki kj kk kl km kn ko kr ks ku kv kw ky la lb lg li ln lo lt lu lv mg mh mi mk
+ml mn mr ms mt my na nb nd ne ng nl nn no nr nv ny oc oj om or os pa pi pl ps
+pt qu rm rn ro ru rw sa sc sd se sg si sk sl sm sn so sq sr ss st su sv sw ta
+te tg th ti tk tl tn to tr ts tt tw ty ug uk ur uz ve vi vo wa wo xh yi yo za
+zh zu`)
+
+// isLanguage reports whether `code` is a language code (see `langPattern`)
+// for a known language.
+func isLanguage(code string) bool {
+	m := langPattern.FindStringSubmatch(code)
+	return m != nil && core.StringInSlice(m[1], languages)
+}
+
+// isSidecar reports whether the file at `path` (e.g., `Rule.de.yml`) holds
+// translations for another rule rather than a rule of its own.
+func isSidecar(path string) bool {
+	base := strings.TrimSuffix(filepath.Base(path), ".yml")
+
+	idx := strings.LastIndex(base, ".")
+	if idx < 0 || !isLanguage(base[idx+1:]) {
+		return false
+	}
+
+	return core.FileExists(filepath.Join(filepath.Dir(path), base[:idx]+".yml"))
+}
+
+// loadTranslations collects the rule's per-language messages -- both inline
+// and from sidecar files -- into its `translations` key, leaving a single
+// default `message` and `description` in their place.
+func loadTranslations(generic baseCheck, path string) error {
+	translations := map[string]Translation{}
+	template, _ := generic["template"].(bool)
+
+	for _, key := range []string{"message", "description"} {
+		localized, ok := toStringMap(generic[key])
+		if !ok {
+			continue
+		} else if len(localized) == 0 {
+			return core.NewE201FromTarget("expected at least one language", key, path)
+		}
+
+		for lang, text := range localized {
+			if err := checkMessage(template, key, text, path); err != nil {
+				return err
+			}
+
+			t := translations[lang]
+			if key == "message" {
+				t.Message = text
+			} else {
+				t.Description = text
+			}
+			translations[lang] = t
+		}
+		generic[key] = localized[pickLang(localized)]
+	}
+
+	sidecars, _ := filepath.Glob(strings.TrimSuffix(path, ".yml") + ".*.yml")
+	for _, sidecar := range sidecars {
+		if !isSidecar(sidecar) {
+			continue
+		}
+		lang := strings.TrimPrefix(
+			strings.TrimSuffix(sidecar, ".yml"),
+			strings.TrimSuffix(path, ".yml")+".")
+
+		b, err := os.ReadFile(sidecar)
+		if err != nil {
+			return core.NewE201FromPosition(err.Error(), sidecar, 1)
+		}
+
+		// NOTE: We can't use `parse` since sidecars aren't rules.
+		fields := map[string]interface{}{}
+		if err = yaml.Unmarshal(b, &fields); err != nil {
+			return core.NewE201FromPosition(err.Error(), sidecar, 1)
+		}
+
+		t := translations[lang]
+		for key, value := range fields {
+			text, ok := value.(string)
+			if ok {
+				if err = checkMessage(template, key, text, sidecar); err != nil {
+					return err
+				}
+			}
+
+			switch {
+			case key == "message" && ok:
+				t.Message = text
+			case key == "description" && ok:
+				t.Description = text
+			default:
+				msg := fmt.Sprintf(
+					"'%s' translates '%s', so it may only set 'message' and 'description' (not '%s')",
+					filepath.Base(sidecar), filepath.Base(path), key)
+				return core.NewE201FromTarget(msg, key, sidecar)
+			}
+		}
+		translations[lang] = t
+	}
+
+	if len(translations) > 0 {
+		generic["translations"] = translations
+	}
+
+	return nil
+}
+
+// toStringMap converts a YAML mapping of languages to strings.
+func toStringMap(value interface{}) (map[string]string, bool) {
+	converted := map[string]string{}
+	switch m := value.(type) {
+	case map[interface{}]interface{}:
+		for k, v := range m {
+			converted[fmt.Sprint(k)] = fmt.Sprint(v)
+		}
+	case map[string]interface{}:
+		for k, v := range m {
+			converted[k] = fmt.Sprint(v)
+		}
+	default:
+		return converted, false
+	}
+	return converted, true
+}
+
+// pickLang returns the language to use as a rule's default.
+func pickLang(localized map[string]string) string {
+	if _, ok := localized[defaultLang]; ok {
+		return defaultLang
+	}
+
+	langs := []string{}
+	for lang := range localized {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	return langs[0]
+}
+
+// translation finds the best translation for `lang`, falling back from a
+// regional variant (e.g., `de_CH`) to its base language.
+func translation(translations map[string]Translation, lang string) (Translation, bool) {
+	if t, ok := translations[lang]; ok {
+		return t, true
+	}
+
+	base := strings.FieldsFunc(lang, func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+	if len(base) > 0 {
+		if t, ok := translations[strings.ToLower(base[0])]; ok {
+			return t, true
+		}
+	}
+
+	return Translation{}, false
+}
+
+// Localize replaces the alert's message and description with their
+// translations into `lang`, if the rule that created it has any.
+func Localize(a *core.Alert, def Definition, lang string) {
+	t, ok := translation(def.Translations, lang)
+	if !ok || (t.Message == def.Message && t.Description == def.Description) {
+		// The alert already uses this translation.
+		return
+	}
+
+	msg, desc := formatMessages(def.Template, t.Message, t.Description, a.Args...)
+	if t.Message != "" {
+		a.Message = msg
+	}
+	if t.Description != "" {
+		a.Description = desc
+	}
+}
@@ -0,0 +1,131 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+func TestLoadTranslations(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "Rule.yml")
+	files := map[string]string{
+		path:                               "extends: existence\n",
+		filepath.Join(dir, "Rule.ja.yml"):  "message: \"「%s」は避けてください。\"\n",
+		filepath.Join(dir, "Other.yml"):    "extends: existence\n",
+		filepath.Join(dir, "Rule.old.yml"): "extends: existence\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !isSidecar(filepath.Join(dir, "Rule.ja.yml")) {
+		t.Error("expected 'Rule.ja.yml' to be a sidecar")
+	} else if isSidecar(path) || isSidecar(filepath.Join(dir, "Missing.de.yml")) {
+		t.Error("expected only 'Rule.ja.yml' to be a sidecar")
+	} else if isSidecar(filepath.Join(dir, "Rule.old.yml")) {
+		t.Error("expected 'Rule.old.yml' (which isn't named for a language) to be a rule")
+	}
+
+	generic := baseCheck{
+		"message": map[interface{}]interface{}{
+			"de": "Vermeiden Sie '%s'.",
+			"en": "Avoid '%s'.",
+		},
+		"description": "It's vague.",
+	}
+	if err := loadTranslations(generic, path); err != nil {
+		t.Fatal(err)
+	}
+
+	if generic["message"] != "Avoid '%s'." {
+		t.Errorf("unexpected default message: %v", generic["message"])
+	}
+
+	translations := generic["translations"].(map[string]Translation)
+	for _, lang := range []string{"en", "de", "ja"} {
+		if _, ok := translations[lang]; !ok {
+			t.Errorf("missing translation for '%s'", lang)
+		}
+	}
+}
+
+func TestInvalidTranslations(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "Rule.yml")
+	if err := os.WriteFile(path, []byte("extends: existence\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	generic := baseCheck{
+		"template": true,
+		"message": map[interface{}]interface{}{
+			"de": "Vermeiden Sie '{{ .Match'.",
+			"en": "Avoid '%s'.",
+		},
+	}
+	if err := loadTranslations(generic, path); err == nil {
+		t.Error("expected an error for an invalid inline translation")
+	}
+
+	sidecar := filepath.Join(dir, "Rule.ja.yml")
+	if err := os.WriteFile(sidecar, []byte("description: \"{{ .Match\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	err := loadTranslations(baseCheck{"template": true, "message": "Avoid '%s'."}, path)
+	if err == nil {
+		t.Fatal("expected an error for an invalid translations file")
+	} else if !strings.Contains(err.Error(), "Rule.ja.yml") {
+		t.Errorf("expected the error to be reported against the translations file: %v", err)
+	}
+
+	if err = os.WriteFile(sidecar, []byte("extends: existence\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	err = loadTranslations(baseCheck{"message": "Avoid '%s'."}, path)
+	if err == nil || !strings.Contains(err.Error(), "may only set 'message' and 'description'") {
+		t.Errorf("expected an error for a rule named like a translations file, got %v", err)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	def := Definition{
+		Message:     "Avoid '%s'.",
+		Description: "It's vague.",
+		Translations: map[string]Translation{
+			"en": {Message: "Avoid '%s'.", Description: "It's vague."},
+			"de": {Message: "Vermeiden Sie '%s'."},
+		},
+	}
+
+	cases := []struct {
+		lang     string
+		expected string
+	}{
+		{"en", "Avoid 'very'."},
+		{"de", "Vermeiden Sie 'very'."},
+		{"de_CH", "Vermeiden Sie 'very'."},
+		{"fr", "Avoid 'very'."},
+	}
+
+	for _, c := range cases {
+		a := core.Alert{Match: "very"}
+		setMessages(&a, def, a.Match)
+
+		Localize(&a, def, c.lang)
+		if a.Message != c.expected {
+			t.Errorf("%s: expected = %q, got = %q", c.lang, c.expected, a.Message)
+		} else if a.Description != "It's vague." {
+			t.Errorf("%s: unexpected description %q", c.lang, a.Description)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package check
 
 import (
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/errata-ai/regexp2"
 	"github.com/jdkato/titlecase"
@@ -117,11 +119,54 @@ func sentence(s string, indicators []string, except *regexp2.Regexp, threshold f
 	return (count / words) >= threshold
 }
 
+// toSentence converts `s` to sentence case, leaving acronyms, exceptions, and
+// words that follow an indicator as they are.
+func toSentence(s string, indicators []string, except *regexp2.Regexp) string {
+	ps := `[\p{N}\p{L}*]+[^\s]*`
+	if except != nil && except.String() != "" {
+		ps = except.String() + "|" + ps
+	}
+	re := regexp2.MustCompileStd(ps)
+
+	i, prev := 0, ""
+	converted, err := re.ReplaceFunc(s, func(m regexp2.Match) string {
+		w := m.String()
+
+		// NOTE: We don't consider single letters (other than "I") to be
+		// acronyms, so that, e.g., "A" in "Creating A Connection" is lowered.
+		acronym := w == strings.ToUpper(w) && (utf8.RuneCountInString(w) > 1 || w == "I")
+
+		t := w
+		if !acronym && !hasAnySuffix(prev, indicators) && !isMatch(except, w) {
+			t = strings.ToLower(w)
+			if i == 0 {
+				r, size := utf8.DecodeRuneInString(t)
+				t = string(unicode.ToUpper(r)) + t[size:]
+			}
+		}
+
+		i++
+		prev = w
+
+		return t
+	}, -1, -1)
+
+	if err != nil {
+		return s
+	}
+	return converted
+}
+
 var varToFunc = map[string]func(string, *regexp2.Regexp) bool{
 	"$lower": lower,
 	"$upper": upper,
 }
 
+var varToConversion = map[string]func(string) string{
+	"$lower": strings.ToLower,
+	"$upper": strings.ToUpper,
+}
+
 var readabilityMetrics = []string{
 	"Gunning Fog",
 	"Coleman-Liau",
@@ -75,3 +75,39 @@ func TestSentence(t *testing.T) {
 		}
 	}
 }
+
+func TestToSentence(t *testing.T) {
+	cases := []struct {
+		heading    string
+		expected   string
+		exceptions []string
+		indicators []string
+	}{
+		{heading: "Non-member Predicates", expected: "Non-member predicates"},
+		{heading: "using the API Gateway", expected: "Using the API gateway"},
+		{
+			heading:    "Find The Thief: Introduction",
+			expected:   "Find the thief: Introduction",
+			indicators: []string{":"},
+		},
+		{
+			heading:    "Creating A Connection To Event Store",
+			expected:   "Creating a connection to Event Store",
+			exceptions: []string{"Event Store"},
+		},
+	}
+
+	for _, c := range cases {
+		var r *regexp2.Regexp
+		if len(c.exceptions) > 0 {
+			r = regexp2.MustCompileStd(strings.Join(c.exceptions, "|"))
+		}
+
+		if s := toSentence(c.heading, c.indicators, r); s != c.expected {
+			t.Errorf("expected = %q, got = %q", c.expected, s)
+		}
+		if !sentence(toSentence(c.heading, c.indicators, r), c.indicators, r, 1) {
+			t.Errorf("%q isn't in sentence case", c.expected)
+		}
+	}
+}
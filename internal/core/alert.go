@@ -1,13 +1,68 @@
 package core
 
-// AlertLevels holds the possible values for "level" in an external rule.
-var AlertLevels = []string{"suggestion", "warning", "error"}
-
-// LevelToInt allows us to easily compare levels in lint.go.
-var LevelToInt = map[string]int{
-	"suggestion": 0,
-	"warning":    1,
-	"error":      2,
+import (
+	"fmt"
+	"strings"
+)
+
+// builtinLevels are the levels that every configuration must support.
+//
+// These are also the default levels, which may be extended through the
+// `AlertLevels` key in `.vale.ini` (see `Config.SetAlertLevels`).
+var builtinLevels = []string{"suggestion", "warning", "error"}
+
+// SetAlertLevels replaces the configuration's alert levels with `levels`,
+// which must be ordered from least to most severe and include the built-in
+// levels in their usual order.
+func (c *Config) SetAlertLevels(levels []string) error {
+	positions := []int{}
+	for _, level := range builtinLevels {
+		idx := indexOf(level, levels)
+		if idx < 0 {
+			return fmt.Errorf("'%s' is a required level", level)
+		}
+		positions = append(positions, idx)
+	}
+
+	if positions[0] > positions[1] || positions[1] > positions[2] {
+		return fmt.Errorf(
+			"levels must keep the order %s", strings.Join(builtinLevels, " < "))
+	}
+
+	mapping := make(map[string]int)
+	for i, level := range levels {
+		if _, found := mapping[level]; found {
+			return fmt.Errorf("'%s' is defined more than once", level)
+		}
+		mapping[level] = i
+	}
+
+	c.AlertLevels = levels
+	c.LevelToInt = mapping
+
+	return nil
+}
+
+// LevelGroup returns the built-in level that `level` is reported under in
+// summaries: custom levels are grouped with the nearest built-in level below
+// them (or "suggestion", if there isn't one).
+func (c *Config) LevelGroup(level string) string {
+	group := builtinLevels[0]
+	for _, builtin := range builtinLevels {
+		if c.LevelToInt[level] >= c.LevelToInt[builtin] {
+			group = builtin
+		}
+	}
+	return group
+}
+
+func indexOf(s string, slice []string) int {
+	for i, v := range slice {
+		if v == s {
+			return i
+		}
+	}
+	return -1
 }
 
 // An Action represents a possible solution to an Alert.
@@ -16,11 +71,20 @@ type Action struct {
 	Params []string // a slice of parameters for the given action
 }
 
+// An Edit is a concrete change that resolves an Alert.
+type Edit struct {
+	Range   []int  // the [begin, end) byte offsets of the text to replace
+	NewText string // the replacement text
+}
+
 // An Alert represents a potential error in prose.
 type Alert struct {
 	Action      Action   // a possible solution
 	Span        []int    // the [begin, end] location within a line
+	ByteSpan    []int    // the [begin, end) byte offsets within the file
 	Offset      []string `json:"-"` // tokens to ignore before this match
+	Groups      []string `json:"-"` // the match followed by its captured groups
+	Args        []string `json:"-"` // the values substituted into `Message`
 	Check       string   // the name of the check
 	Description string   // why `Message` is meaningful
 	Link        string   // reference material
@@ -28,6 +92,18 @@ type Alert struct {
 	Severity    string   // 'suggestion', 'warning', or 'error'
 	Match       string   // the actual matched text
 	Line        int      // the source line
+	EndLine     int      // the source line on which the match ends
+	EndColumn   int      // the column of the match's last character (on `EndLine`)
+
+	// UTF16Characters is the [begin, end) range of the match as Language
+	// Server Protocol characters: 0-based offsets, in UTF-16 code units, into
+	// `Line` and `EndLine`. (Unlike `Span`, which is 1-based and counts
+	// runes.)
+	UTF16Characters []int
+
+	Fingerprint string   // a stable identifier for the finding (see `SetFingerprints`)
+	Tags        []string // the categories of the check (e.g., 'legal')
+	Suggestions []Edit   // ready-made fixes, in order of preference
 	Limit       int      `json:"-"` // the max times to report
 	Hide        bool     `json:"-"` // should we hide this alert?
 }
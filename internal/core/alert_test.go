@@ -0,0 +1,46 @@
+package core
+
+import "testing"
+
+func TestSetAlertLevels(t *testing.T) {
+	cfg, err := NewConfig(&CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, bad := range [][]string{
+		{"suggestion", "warning"},
+		{"warning", "suggestion", "error"},
+		{"suggestion", "warning", "error", "warning"},
+	} {
+		if err = cfg.SetAlertLevels(bad); err == nil {
+			t.Errorf("expected an error for %v", bad)
+		}
+	}
+
+	err = cfg.SetAlertLevels([]string{"suggestion", "info", "warning", "error", "blocker"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groups := map[string]string{
+		"info":    "suggestion",
+		"warning": "warning",
+		"blocker": "error",
+	}
+	for level, group := range groups {
+		if got := cfg.LevelGroup(level); got != group {
+			t.Errorf("%s: expected group '%s', got '%s'", level, group, got)
+		}
+	}
+
+	// Other configurations keep the default levels.
+	other, err := NewConfig(&CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	} else if _, found := other.LevelToInt["blocker"]; found {
+		t.Errorf("expected 'blocker' to be undefined, got %v", other.AlertLevels)
+	} else if other.LevelToInt["error"] != 2 {
+		t.Errorf("expected 'error' to be 2, got %d", other.LevelToInt["error"])
+	}
+}
@@ -16,52 +16,62 @@ import (
 //
 // For example, `vale --minAlertLevel=error`.
 type CLIFlags struct {
-	AlertLevel string
-	Built      string
-	Glob       string
-	InExt      string
-	Output     string
-	Path       string
-	Sources    string
-	Filter     string
-	Local      bool
-	NoExit     bool
-	Normalize  bool
-	Relative   bool
-	Remote     bool
-	Simple     bool
-	Sorted     bool
-	Wrap       bool
-	Version    bool
-	Help       bool
+	AlertLevel   string
+	FailOn       string
+	Built        string
+	Glob         string
+	InExt        string
+	Output       string
+	Path         string
+	Sources      string
+	Filter       string
+	Tags         string
+	Local        bool
+	NoExit       bool
+	Normalize    bool
+	Relative     bool
+	Remote       bool
+	ReportUnused bool
+	Simple       bool
+	Strict       bool
+	Trace        bool
+	Sorted       bool
+	Wrap         bool
+	Version      bool
+	Help         bool
 }
 
 // Config holds the configuration values from both the CLI and `.vale.ini`.
 type Config struct {
 	// General configuration
-	BlockIgnores   map[string][]string        // A list of blocks to ignore
-	Checks         []string                   // All checks to load
-	Formats        map[string]string          // A map of unknown -> known formats
-	Asciidoctor    map[string]string          // A map of asciidoctor attributes
-	FormatToLang   map[string]string          // A map of format to lang ID
-	GBaseStyles    []string                   // Global base style
-	GChecks        map[string]bool            // Global checks
-	IgnoredClasses []string                   // A list of HTML classes to ignore
-	IgnoredScopes  []string                   // A list of HTML tags to ignore
-	MinAlertLevel  int                        // Lowest alert level to display
-	Vocab          []string                   // The active project
-	RuleToLevel    map[string]string          // Single-rule level changes
-	SBaseStyles    map[string][]string        // Syntax-specific base styles
-	SChecks        map[string]map[string]bool // Syntax-specific checks
-	SkippedScopes  []string                   // A list of HTML blocks to ignore
-	Stylesheets    map[string]string          // XSLT stylesheet
-	StylesPath     string                     // Directory with Rule.yml files
-	TokenIgnores   map[string][]string        // A list of tokens to ignore
-	WordTemplate   string                     // The template used in YAML -> regexp list conversions
-	RootINI        string                     // the path to the project's .vale.ini file
+	BlockIgnores   map[string][]string          // A list of blocks to ignore
+	Checks         []string                     // All checks to load
+	Formats        map[string]string            // A map of unknown -> known formats
+	Asciidoctor    map[string]string            // A map of asciidoctor attributes
+	FormatToLang   map[string]string            // A map of format to lang ID
+	GBaseStyles    []string                     // Global base style
+	GChecks        map[string]bool              // Global checks
+	IgnoredClasses []string                     // A list of HTML classes to ignore
+	IgnoredScopes  []string                     // A list of HTML tags to ignore
+	AlertLevels    []string                     // Available levels, from least to most severe
+	MinAlertLevel  int                          // Lowest alert level to display
+	Vocab          []string                     // The active project
+	RuleToLevel    map[string]string            // Single-rule level changes
+	TagToLevel     map[string]string            // Level changes for tagged rules
+	SBaseStyles    map[string][]string          // Syntax-specific base styles
+	SChecks        map[string]map[string]bool   // Syntax-specific checks
+	SMinAlertLevel map[string]int               // Syntax-specific minimum alert levels
+	SRuleToLevel   map[string]map[string]string // Syntax-specific single-rule level changes
+	SkippedScopes  []string                     // A list of HTML blocks to ignore
+	Stylesheets    map[string]string            // XSLT stylesheet
+	StylesPath     string                       // Directory with Rule.yml files
+	TokenIgnores   map[string][]string          // A list of tokens to ignore
+	WordTemplate   string                       // The template used in YAML -> regexp list conversions
+	RootINI        string                       // the path to the project's .vale.ini file
 
 	AcceptedTokens map[string]struct{} `json:"-"` // Project-specific vocabulary (okay)
 	RejectedTokens map[string]struct{} `json:"-"` // Project-specific vocabulary (avoid)
+	LevelToInt     map[string]int      `json:"-"` // Each level's index in AlertLevels
 
 	DictionaryPath string // Location to search for dictionaries.
 
@@ -72,12 +82,17 @@ type Config struct {
 	Root         string               `json:"-"`
 
 	NLPEndpoint string // An external API to call for NLP-related work.
+	StrictRules bool   // Reject rule definitions with unknown keys.
+	MessageLang string // The language of rule messages (if not each file's).
 
 	// Command-line configuration
 	Flags *CLIFlags `json:"-"`
 
 	StyleKeys []string `json:"-"`
 	RuleKeys  []string `json:"-"`
+
+	origin string       // how the main config file (`Flags.Path`) was provided
+	trace  []TraceEntry // the values each config file contributed (see `Trace`)
 }
 
 // NewConfig initializes a Config with its default values.
@@ -91,15 +106,21 @@ func NewConfig(flags *CLIFlags) (*Config, error) {
 	cfg.Asciidoctor = make(map[string]string)
 	cfg.GChecks = make(map[string]bool)
 	cfg.MinAlertLevel = 1
+	cfg.AlertLevels = []string{"suggestion", "warning", "error"}
+	cfg.LevelToInt = map[string]int{"suggestion": 0, "warning": 1, "error": 2}
 	cfg.RejectedTokens = make(map[string]struct{})
 	cfg.RuleToLevel = make(map[string]string)
+	cfg.TagToLevel = make(map[string]string)
 	cfg.SBaseStyles = make(map[string][]string)
 	cfg.SChecks = make(map[string]map[string]bool)
+	cfg.SMinAlertLevel = make(map[string]int)
+	cfg.SRuleToLevel = make(map[string]map[string]string)
 	cfg.SecToPat = make(map[string]glob.Glob)
 	cfg.Stylesheets = make(map[string]string)
 	cfg.TokenIgnores = make(map[string][]string)
 	cfg.Paths = []string{""}
 	cfg.FormatToLang = make(map[string]string)
+	cfg.StrictRules = flags.Strict
 
 	return &cfg, nil
 }
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf16"
 	"unicode/utf8"
 
 	"github.com/errata-ai/vale/v2/internal/nlp"
@@ -19,26 +20,57 @@ var commentControlRE = regexp.MustCompile(`^vale (.+\..+) = (YES|NO)$`)
 
 // A File represents a linted text file.
 type File struct {
-	NLP        nlp.Info          // -
-	Summary    bytes.Buffer      // holds content to be included in summarization checks
-	Alerts     []Alert           // all alerts associated with this file
-	BaseStyles []string          // base style assigned in .vale
-	Lines      []string          // the File's Content split into lines
-	Sequences  []string          // tracks various info (e.g., defined abbreviations)
-	Content    string            // the raw file contents
-	Format     string            // 'code', 'markup' or 'prose'
-	NormedExt  string            // the normalized extension (see util/format.go)
-	Path       string            // the full path
-	Transform  string            // XLST transform
-	RealExt    string            // actual file extension
-	Checks     map[string]bool   // syntax-specific checks assigned in .vale
-	ChkToCtx   map[string]string // maps a temporary context to a particular check
-	Comments   map[string]bool   // comment control statements
-	Metrics    map[string]int    // count-based metrics
-	history    map[string]int    // -
-	limits     map[string]int    // -
-	simple     bool              // -
-	Lookup     bool              // -
+	NLP          nlp.Info                // -
+	Summary      bytes.Buffer            // holds content to be included in summarization checks
+	Alerts       []Alert                 // all alerts associated with this file
+	Candidates   []Alert                 // alerts pending a project-wide decision
+	Outline      []Heading               // the file's headings, in order
+	Sections     []Section               // the file's sections, in order
+	BaseStyles   []string                // base style assigned in .vale
+	Lines        []string                // the File's Content split into lines
+	Sequences    []string                // tracks various info (e.g., defined abbreviations)
+	Content      string                  // the raw file contents
+	Format       string                  // 'code', 'markup' or 'prose'
+	NormedExt    string                  // the normalized extension (see util/format.go)
+	Path         string                  // the full path
+	Transform    string                  // XLST transform
+	RealExt      string                  // actual file extension
+	Checks       map[string]bool         // syntax-specific checks assigned in .vale
+	ChkToCtx     map[string]string       // maps a temporary context to a particular check
+	Comments     map[string]bool         // comment control statements
+	Suppressions []*Suppression          // in-text comments that disable rules
+	Metrics      map[string]int          // count-based metrics
+	Levels       map[string]string       // syntax-specific single-rule level changes
+	MinLevel     int                     // the lowest alert level to report
+	history      map[string]int          // -
+	srcMap       *SourceMap              // -
+	active       map[string]*Suppression // -
+	limits       map[string]int          // -
+	simple       bool                    // -
+	Lookup       bool                    // -
+}
+
+// A Heading is an entry in a File's outline.
+type Heading struct {
+	Level int    // 1 for `h1`, 2 for `h2`, etc.
+	Text  string // the heading's text
+}
+
+// A Section is the content under one of a File's headings (or before the
+// first one).
+type Section struct {
+	Line    int            // the line on which the section starts
+	Metrics map[string]int // the number of times each markup scope occurs in it
+}
+
+// SectionAt returns the section that starts on `line`.
+func (f *File) SectionAt(line int) (Section, bool) {
+	for _, s := range f.Sections {
+		if s.Line == line {
+			return s, true
+		}
+	}
+	return Section{}, false
 }
 
 // NewFile initializes a File.
@@ -80,6 +112,7 @@ func NewFile(src string, config *Config) (*File, error) {
 			checks = config.SChecks[sec]
 		}
 	}
+	minLevel, levels := fileLevels(fp, config)
 
 	lang := "en"
 	for syntax, code := range config.FormatToLang {
@@ -114,8 +147,10 @@ func NewFile(src string, config *Config) (*File, error) {
 		NormedExt: ext, Format: format, RealExt: filepath.Ext(src),
 		BaseStyles: baseStyles, Checks: checks, Lines: lines, Content: content,
 		Comments: make(map[string]bool), history: make(map[string]int),
+		active: make(map[string]*Suppression),
 		simple: config.Flags.Simple, Transform: transform,
 		limits: make(map[string]int), Path: src, Metrics: make(map[string]int),
+		Levels: levels, MinLevel: minLevel,
 		NLP:    nlp.Info{Endpoint: config.NLPEndpoint, Lang: lang},
 		Lookup: lookup,
 	}
@@ -123,6 +158,43 @@ func NewFile(src string, config *Config) (*File, error) {
 	return &file, nil
 }
 
+// fileLevels computes the minimum alert level and rule-level changes that
+// apply to the file at `fp`.
+//
+// Later sections take precedence over earlier ones, but a level given on the
+// command line (`--minAlertLevel`) always wins.
+func fileLevels(fp string, config *Config) (int, map[string]string) {
+	fromCLI := StringInSlice(config.Flags.AlertLevel, config.AlertLevels)
+
+	minLevel := config.MinAlertLevel
+	if level, found := config.SMinAlertLevel["*"]; found && !fromCLI {
+		minLevel = level
+	}
+
+	levels := make(map[string]string)
+	for _, sec := range config.RuleKeys {
+		if pat, found := config.SecToPat[sec]; found && pat.Match(fp) {
+			if level, ok := config.SMinAlertLevel[sec]; ok && !fromCLI {
+				minLevel = level
+			}
+			for rule, level := range config.SRuleToLevel[sec] {
+				levels[rule] = level
+			}
+		}
+	}
+
+	return minLevel, levels
+}
+
+// RuleLevel returns the level of the rule `name` for this file, falling back
+// to the rule's own level (`level`) if it hasn't been changed.
+func (f *File) RuleLevel(name, level string) string {
+	if changed, found := f.Levels[name]; found {
+		return changed
+	}
+	return level
+}
+
 // SortedAlerts returns all of f's alerts sorted by line and column.
 func (f *File) SortedAlerts() []Alert {
 	sort.Sort(ByPosition(f.Alerts))
@@ -131,8 +203,14 @@ func (f *File) SortedAlerts() []Alert {
 
 // ComputeMetrics returns all of f's metrics.
 func (f *File) ComputeMetrics() (map[string]interface{}, error) {
+	return TextMetrics(f.Metrics, f.Summary.String())
+}
+
+// TextMetrics returns the metrics of `text` (summarized content), given the
+// number of times each markup scope (e.g., `heading.h2`) occurs in it.
+func TextMetrics(counts map[string]int, text string) (map[string]interface{}, error) {
 	params := map[string]interface{}{}
-	for k, v := range f.Metrics {
+	for k, v := range counts {
 		if strings.HasPrefix(k, "table") {
 			continue
 		}
@@ -140,7 +218,7 @@ func (f *File) ComputeMetrics() (map[string]interface{}, error) {
 		params[k] = float64(v)
 	}
 
-	doc := summarize.NewDocument(f.Summary.String())
+	doc := summarize.NewDocument(text)
 	if doc.NumWords == 0 {
 		return params, nil
 	}
@@ -227,6 +305,56 @@ func (f *File) SetText(s string) {
 	f.Content = s
 	f.Lines = strings.SplitAfter(s, "\n")
 	f.history = map[string]int{}
+	f.srcMap = nil
+}
+
+// SourceMap returns a SourceMap for the file's original content.
+func (f *File) SourceMap() *SourceMap {
+	if f.srcMap == nil {
+		// NOTE: We use `f.Lines` since `f.Content` may have been edited in
+		// place (see lint/walk.go).
+		f.srcMap = NewSourceMap(strings.Join(f.Lines, ""))
+	}
+	return f.srcMap
+}
+
+// setRange computes the full extent of an Alert from its starting position.
+//
+// If we can find the alert's match in the source, its end can be on a
+// different line; otherwise, we fall back to the same-line span calculated
+// by `FindLoc` or `assignLoc`.
+//
+// NOTE: `Span` keeps its meaning (a range on the starting line); the end of
+// a match is given by `EndLine` and `EndColumn` (inclusive, like `Span`) and
+// by `UTF16Characters` (exclusive and 0-based, as in the Language Server
+// Protocol).
+func (f *File) setRange(a *Alert) {
+	m := f.SourceMap()
+
+	start := m.Offset(a.Line, a.Span[0])
+	end := m.Extent(start, a.Match)
+	if end < 0 {
+		end = m.Offset(a.Line, a.Span[1]+1)
+		if end <= start {
+			end = start
+		}
+	}
+
+	a.EndLine, a.EndColumn = a.Line, a.Span[1]
+	a.UTF16Characters = []int{m.UTF16Character(start), m.UTF16Character(start)}
+	if end > start {
+		r, size := utf8.DecodeLastRuneInString(m.Content()[:end])
+		a.EndLine, a.EndColumn = m.Position(end - size)
+		a.UTF16Characters[1] = m.UTF16Character(end-size) + len(utf16.Encode([]rune{r}))
+	}
+
+	a.ByteSpan = []int{start, end}
+	for i := range a.Suggestions {
+		if a.Suggestions[i].Range == nil {
+			// By default, an edit replaces the entire match.
+			a.Suggestions[i].Range = []int{start, end}
+		}
+	}
 }
 
 // AddAlert calculates the in-text location of an Alert and adds it to a File.
@@ -240,16 +368,18 @@ func (f *File) AddAlert(a Alert, blk nlp.Block, lines, pad int, lookup bool) {
 		a.Offset = append(a.Offset, strings.Fields(ctx[0:a.Span[0]])...)
 	}
 
-	if !lookup {
+	mapped := f.mapLoc(blk, &a)
+	if !mapped && !lookup {
 		a.Line, a.Span = f.assignLoc(ctx, blk, pad, a)
 	}
-	if (!lookup && a.Span[0] < 0) || lookup {
+	if !mapped && ((!lookup && a.Span[0] < 0) || lookup) {
 		a.Line, a.Span = f.FindLoc(ctx, blk.Text, pad, lines, a)
 	}
 
 	if a.Span[0] > 0 {
 		f.ChkToCtx[a.Check], _ = Substitute(ctx, a.Match, '#')
-		if !a.Hide {
+		f.setRange(&a)
+		if !a.Hide && !f.isSuppressed(a) {
 			// Ensure that we're not double-reporting an Alert:
 			entry := strings.Join([]string{
 				strconv.Itoa(a.Line),
@@ -272,16 +402,68 @@ func (f *File) AddAlert(a Alert, blk nlp.Block, lines, pad int, lookup bool) {
 	}
 }
 
-// UpdateComments sets a new status based on comment.
-func (f *File) UpdateComments(comment string) {
+// UpdateComments sets a new status based on comment, which was found at
+// `line` (0 if unknown).
+//
+// In addition to the block-level `vale off`, `vale on`, and `vale
+// Style.Rule = YES|NO` (where `Style.*` matches every rule in a style), we
+// support line-based suppressions:
+//
+//	vale-ignore-next-line [Style.Rule ...]
+//	vale-ignore-start [Style.Rule ...]
+//	vale-ignore-end
+//
+// Any of these may include a reason after `--`.
+//
+// NOTE: A line-based suppression whose line is unknown doesn't cover any
+// lines (see `Suppression.covers`), since we'd otherwise anchor it at the
+// start of the file.
+func (f *File) UpdateComments(comment string, line int) {
+	comment, reason := splitReason(comment)
+
+	directive, rest, _ := strings.Cut(comment, " ")
+	switch directive {
+	case "vale-ignore-next-line":
+		s := &Suppression{
+			Directive: comment, Rules: parseRules(rest), Reason: reason,
+			Line: line}
+		if line > 0 {
+			s.first, s.last = line+1, line+1
+		}
+		f.suppress("", s)
+		return
+	case "vale-ignore-start":
+		s := &Suppression{
+			Directive: comment, Rules: parseRules(rest), Reason: reason,
+			Line: line}
+		if line > 0 {
+			s.first = line + 1
+		}
+		f.suppress("ignore", s)
+		return
+	case "vale-ignore-end":
+		f.release("ignore", line)
+		return
+	}
+
 	if comment == "vale off" { //nolint:gocritic
 		f.Comments["off"] = true
+		f.suppress("off", &Suppression{
+			Directive: comment, Rules: []string{}, Reason: reason, Line: line})
 	} else if comment == "vale on" {
 		f.Comments["off"] = false
+		f.release("off", line)
 	} else if commentControlRE.MatchString(comment) {
 		check := commentControlRE.FindStringSubmatch(comment)
 		if len(check) == 3 {
 			f.Comments[check[1]] = check[2] == "NO"
+			if check[2] == "NO" {
+				f.suppress(check[1], &Suppression{
+					Directive: comment, Rules: []string{check[1]},
+					Reason: reason, Line: line})
+			} else {
+				f.release(check[1], line)
+			}
 		}
 	}
 }
@@ -289,7 +471,7 @@ func (f *File) UpdateComments(comment string) {
 // QueryComments checks if there has been an in-text comment for this check.
 func (f *File) QueryComments(check string) bool {
 	if !f.Comments["off"] {
-		if status, ok := f.Comments[check]; ok {
+		if status, ok := f.Comments[f.commentKey(check)]; ok {
 			return status
 		}
 	}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fingerprintWindow is the maximum number of bytes of context, on each side
+// of an alert's match, included in its fingerprint.
+const fingerprintWindow = 32
+
+// SetFingerprints assigns a `Fingerprint` to each of the file's alerts.
+//
+// A fingerprint identifies the same finding across runs: it's derived from
+// the rule, the file's path (relative to `root`, the directory containing
+// the project's `.vale.ini`), the (whitespace-normalized) match, and the
+// text surrounding it on the same line -- but not from the alert's position
+// or the directory that Vale was run from, so it's unaffected by edits
+// elsewhere in the file. Identical findings (e.g., a repeated sentence) are
+// distinguished by their order of occurrence.
+func (f *File) SetFingerprints(root string) {
+	path := filepath.ToSlash(f.relPath(root))
+
+	// We assign occurrences in positional order without re-ordering the
+	// alerts themselves.
+	order := make([]int, len(f.Alerts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return ByPosition(f.Alerts).Less(order[i], order[j])
+	})
+
+	seen := make(map[string]int)
+	for _, i := range order {
+		a := &f.Alerts[i]
+
+		before, after := f.context(a)
+		key := strings.Join([]string{
+			a.Check,
+			path,
+			WhitespaceToSpace(strings.TrimSpace(a.Match)),
+			before,
+			after}, "\x00")
+
+		seen[key]++
+		sum := sha256.Sum256([]byte(key + "\x00" + strconv.Itoa(seen[key])))
+
+		a.Fingerprint = hex.EncodeToString(sum[:16])
+	}
+}
+
+// relPath returns the file's path relative to `root`, if possible.
+func (f *File) relPath(root string) string {
+	if root == "" || !FileExists(f.Path) {
+		// There's no project (or file, in the case of stdin).
+		return f.Path
+	}
+
+	abs, err := filepath.Abs(f.Path)
+	if err != nil {
+		return f.Path
+	}
+
+	base, err := filepath.Abs(root)
+	if err != nil {
+		return f.Path
+	}
+
+	rel, err := filepath.Rel(base, abs)
+	if err != nil {
+		return f.Path
+	}
+
+	return rel
+}
+
+// context returns the text surrounding an alert's match, limited to the
+// lines it occurs on.
+func (f *File) context(a *Alert) (string, string) {
+	if len(a.ByteSpan) != 2 || f.Format == "fragment" {
+		// Fragments are linted one at a time, so we no longer have the
+		// source that their offsets refer to.
+		return "", ""
+	}
+
+	src := f.SourceMap().Content()
+	start, end := a.ByteSpan[0], a.ByteSpan[1]
+	if start < 0 || end > len(src) || start > end {
+		return "", ""
+	}
+
+	from := start - fingerprintWindow
+	if from < 0 {
+		from = 0
+	}
+	if nl := strings.LastIndexByte(src[from:start], '\n'); nl >= 0 {
+		from += nl + 1
+	}
+
+	to := end + fingerprintWindow
+	if to > len(src) {
+		to = len(src)
+	}
+	if nl := strings.IndexByte(src[end:to], '\n'); nl >= 0 {
+		to = end + nl
+	}
+
+	return WhitespaceToSpace(src[from:start]), WhitespaceToSpace(src[end:to])
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func fingerprints(content string) []string {
+	return fingerprintsAt("doc.md", "", content)
+}
+
+func fingerprintsAt(path, root, content string) []string {
+	f := File{Path: path, Lines: strings.SplitAfter(content, "\n")}
+
+	src := strings.Join(f.Lines, "")
+	for i := 0; ; {
+		idx := strings.Index(src[i:], "TODO")
+		if idx < 0 {
+			break
+		}
+		start := i + idx
+		line, col := f.SourceMap().Position(start)
+		f.Alerts = append(f.Alerts, Alert{
+			Check: "Vale.Annotations", Match: "TODO", Line: line,
+			Span: []int{col, col + 3}, ByteSpan: []int{start, start + 4}})
+		i = start + 4
+	}
+
+	f.SetFingerprints(root)
+
+	found := []string{}
+	for _, a := range f.Alerts {
+		found = append(found, a.Fingerprint)
+	}
+	return found
+}
+
+func TestFingerprints(t *testing.T) {
+	before := fingerprints("A TODO here.\n\nA TODO here.\n")
+	after := fingerprints("New text.\n\nA TODO here.\n\nA TODO here.\n")
+
+	if len(before) != 2 || len(after) != 2 {
+		t.Fatalf("expected 2 alerts, got %v and %v", before, after)
+	} else if before[0] == before[1] {
+		t.Errorf("expected repeated findings to be distinct: %v", before)
+	}
+
+	for i := range before {
+		if before[i] != after[i] {
+			t.Errorf("alert %d: fingerprint changed: %s != %s", i, before[i], after[i])
+		}
+	}
+
+	changed := fingerprints("A TODO there.\n\nA TODO here.\n")
+	if changed[0] == before[0] {
+		t.Error("expected a new fingerprint when the context changes")
+	}
+}
+
+func TestFingerprintRoot(t *testing.T) {
+	root := t.TempDir()
+
+	path := filepath.Join(root, "docs", "doc.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	content := "A TODO here.\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd) //nolint:errcheck
+
+	// The same file, from two different working directories.
+	found := []string{}
+	for _, dir := range []string{root, filepath.Dir(path)} {
+		if err = os.Chdir(dir); err != nil {
+			t.Fatal(err)
+		}
+
+		rel, rerr := filepath.Rel(dir, path)
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		found = append(found, fingerprintsAt(rel, root, content)...)
+	}
+	found = append(found, fingerprintsAt(path, root, content)...)
+
+	if found[0] != found[1] || found[0] != found[2] {
+		t.Errorf("expected the same fingerprint from any directory: %v", found)
+	}
+}
@@ -61,6 +61,17 @@ var syntaxOpts = map[string]func(string, *ini.Section, *Config) error{
 		cfg.FormatToLang[label] = sec.Key("Lang").String()
 		return nil
 	},
+	"MinAlertLevel": func(label string, sec *ini.Section, cfg *Config) error {
+		level := sec.Key("MinAlertLevel").String()
+		if index, found := cfg.LevelToInt[level]; found {
+			cfg.SMinAlertLevel[label] = index
+			return nil
+		}
+		return NewE201FromTarget(
+			fmt.Sprintf("MinAlertLevel must be one of %v.", cfg.AlertLevels),
+			level,
+			cfg.Flags.Path)
+	},
 }
 
 var globalOpts = map[string]func(*ini.Section, *Config, []string){
@@ -83,6 +94,15 @@ var globalOpts = map[string]func(*ini.Section, *Config, []string){
 }
 
 var coreOpts = map[string]func(*ini.Section, *Config, []string) error{
+	"AlertLevels": func(sec *ini.Section, cfg *Config, _ []string) error {
+		levels := mergeValues(sec.Key("AlertLevels").StringsWithShadows(","))
+		if err := cfg.SetAlertLevels(levels); err != nil {
+			return NewE201FromTarget(err.Error(), "AlertLevels", cfg.Flags.Path)
+		}
+		// The default minimum level is still `warning`.
+		cfg.MinAlertLevel = cfg.LevelToInt["warning"]
+		return nil
+	},
 	"StylesPath": func(sec *ini.Section, cfg *Config, args []string) error {
 		paths := sec.Key("StylesPath").ValueWithShadows()
 		if cfg.Flags.Local && len(paths) == 2 {
@@ -107,13 +127,13 @@ var coreOpts = map[string]func(*ini.Section, *Config, []string) error{
 		return nil
 	},
 	"MinAlertLevel": func(sec *ini.Section, cfg *Config, _ []string) error {
-		if !StringInSlice(cfg.Flags.AlertLevel, AlertLevels) {
+		if !StringInSlice(cfg.Flags.AlertLevel, cfg.AlertLevels) {
 			level := sec.Key("MinAlertLevel").String() // .In("suggestion", AlertLevels)
-			if index, found := LevelToInt[level]; found {
+			if index, found := cfg.LevelToInt[level]; found {
 				cfg.MinAlertLevel = index
 			} else {
 				return NewE201FromTarget(
-					"MinAlertLevel must be 'suggestion', 'warning', or 'error'.",
+					fmt.Sprintf("MinAlertLevel must be one of %v.", cfg.AlertLevels),
 					level,
 					cfg.Flags.Path)
 			}
@@ -153,6 +173,14 @@ var coreOpts = map[string]func(*ini.Section, *Config, []string) error{
 		cfg.NLPEndpoint = sec.Key("NLPEndpoint").MustString("")
 		return nil
 	},
+	"StrictRules": func(sec *ini.Section, cfg *Config, _ []string) error { //nolint:unparam
+		cfg.StrictRules = cfg.Flags.Strict || sec.Key("StrictRules").MustBool(false)
+		return nil
+	},
+	"MessageLang": func(sec *ini.Section, cfg *Config, _ []string) error { //nolint:unparam
+		cfg.MessageLang = sec.Key("MessageLang").MustString("")
+		return nil
+	},
 }
 
 func shadowLoad(source interface{}, others ...interface{}) (*ini.File, error) {
@@ -190,14 +218,14 @@ func loadINI(cfg *Config, dry bool) error {
 		}
 	} else if cfg.Flags.Path != "" {
 		// We've been given a value through `--config`.
-		uCfg, err = shadowLoad(cfg.Flags.Path)
+		uCfg, err = loadSource(cfg, cfg.Flags.Path, "--config")
 		if err != nil {
 			return NewE100("invalid --config", err)
 		}
 		cfg.Root = filepath.Dir(cfg.Flags.Path)
 	} else if hasEnv {
 		// We've been given a value through `VALE_CONFIG_PATH`.
-		uCfg, err = shadowLoad(fromEnv)
+		uCfg, err = loadSource(cfg, fromEnv, "VALE_CONFIG_PATH")
 		if err != nil {
 			return NewE100("invalid VALE_CONFIG_PATH", err)
 		}
@@ -205,7 +233,7 @@ func loadINI(cfg *Config, dry bool) error {
 		cfg.Flags.Path = fromEnv
 	} else {
 		// We're using a config file found using a local search process.
-		uCfg, err = shadowLoad(base)
+		uCfg, err = loadSource(cfg, base, "search")
 		if err != nil {
 			return NewE100(".vale.ini not found", err)
 		}
@@ -213,12 +241,18 @@ func loadINI(cfg *Config, dry bool) error {
 		cfg.Flags.Path = base
 	}
 
-	if StringInSlice(cfg.Flags.AlertLevel, AlertLevels) {
-		cfg.MinAlertLevel = LevelToInt[cfg.Flags.AlertLevel]
+	uCfg.BlockMode = false
+	if err = processConfig(uCfg, cfg, sources, dry); err != nil {
+		return err
 	}
 
-	uCfg.BlockMode = false
-	return processConfig(uCfg, cfg, sources, dry)
+	// NOTE: We need to do this after processing the config since it may
+	// define custom alert levels.
+	if StringInSlice(cfg.Flags.AlertLevel, cfg.AlertLevels) {
+		cfg.MinAlertLevel = cfg.LevelToInt[cfg.Flags.AlertLevel]
+	}
+
+	return nil
 }
 
 // loadConfig loads the .vale file. It checks the ancestors of the current
@@ -265,38 +299,51 @@ func loadConfig(names []string) (string, error) {
 }
 
 func processSources(cfg *Config, sources []string) (*ini.File, error) {
-	var uCfg *ini.File
-	var err error
-
 	if len(sources) == 0 {
-		return uCfg, errors.New("no sources provided")
-	} else if len(sources) == 1 {
-		cfg.Flags.Path = sources[0]
-		return shadowLoad(cfg.Flags.Path)
+		return nil, errors.New("no sources provided")
 	}
 
-	t := sources[1:]
-	s := make([]interface{}, len(t))
-	for i, v := range t {
-		s[i] = v
+	origins := make(map[string]string)
+	for _, source := range sources {
+		origins[source] = cfg.sourceOrigin(source)
 	}
 
-	uCfg, err = shadowLoad(sources[0], s...)
+	uCfg, err := cfg.loadSources(sources, func(path string) string {
+		return origins[path]
+	})
 	cfg.Flags.Path = sources[len(sources)-1]
 
 	return uCfg, err
 }
 
+// loadSource loads a single configuration file, provided by `origin`.
+func loadSource(cfg *Config, path, origin string) (*ini.File, error) {
+	cfg.origin = origin
+	return cfg.loadSources([]string{path}, func(string) string {
+		return origin
+	})
+}
+
 func processConfig(uCfg *ini.File, cfg *Config, paths []string, dry bool) error {
 	core := uCfg.Section("")
 	global := uCfg.Section("*")
 
 	formats := uCfg.Section("formats")
 	adoc := uCfg.Section("asciidoctor")
+	tags := uCfg.Section("tags")
+
+	// Custom levels must be known before any level-related settings.
+	if core.HasKey("AlertLevels") {
+		if err := coreOpts["AlertLevels"](core, cfg, paths); err != nil && !dry {
+			return err
+		}
+	}
 
 	// Default settings
 	for _, k := range core.KeyStrings() {
-		if f, found := coreOpts[k]; found {
+		if k == "AlertLevels" {
+			continue
+		} else if f, found := coreOpts[k]; found {
 			if err := f(core, cfg, paths); err != nil && !dry {
 				return err
 			}
@@ -316,22 +363,37 @@ func processConfig(uCfg *ini.File, cfg *Config, paths []string, dry bool) error
 		cfg.Asciidoctor[k] = adoc.Key(k).String()
 	}
 
+	// Tag-based level changes
+	for _, k := range tags.KeyStrings() {
+		level := tags.Key(k).String()
+		if !StringInSlice(level, cfg.AlertLevels) && !dry {
+			msg := fmt.Sprintf("'%s' is not a valid level for tag '%s'", level, k)
+			return NewE201FromTarget(msg, k, cfg.RootINI)
+		}
+		cfg.TagToLevel[k] = level
+	}
+
 	// Global settings
 	for _, k := range global.KeyStrings() {
 		if f, found := globalOpts[k]; found {
 			f(global, cfg, paths)
+		} else if k == "MinAlertLevel" {
+			// This is equivalent to a glob section that matches every file.
+			if err := syntaxOpts[k]("*", global, cfg); err != nil && !dry {
+				return err
+			}
 		} else if _, found = syntaxOpts[k]; found {
 			msg := fmt.Sprintf("'%s' is a syntax-specific option", k)
 			return NewE201FromTarget(msg, k, cfg.RootINI)
 		} else {
-			cfg.GChecks[k] = validateLevel(k, global.Key(k).String(), cfg)
+			cfg.GChecks[k] = validateLevel(k, global.Key(k).String(), cfg.AlertLevels, cfg.RuleToLevel)
 			cfg.Checks = append(cfg.Checks, k)
 		}
 	}
 
 	// Syntax-specific settings
 	for _, sec := range uCfg.SectionStrings() {
-		if StringInSlice(sec, []string{"*", "DEFAULT", "formats", "asciidoctor"}) {
+		if StringInSlice(sec, []string{"*", "DEFAULT", "formats", "asciidoctor", "tags"}) {
 			continue
 		}
 
@@ -342,18 +404,20 @@ func processConfig(uCfg *ini.File, cfg *Config, paths []string, dry bool) error
 		cfg.SecToPat[sec] = pat
 
 		syntaxMap := make(map[string]bool)
+		levelMap := make(map[string]string)
 		for _, k := range uCfg.Section(sec).KeyStrings() {
 			if f, found := syntaxOpts[k]; found {
 				if err = f(sec, uCfg.Section(sec), cfg); err != nil && !dry {
 					return err
 				}
 			} else {
-				syntaxMap[k] = validateLevel(k, uCfg.Section(sec).Key(k).String(), cfg)
+				syntaxMap[k] = validateLevel(k, uCfg.Section(sec).Key(k).String(), cfg.AlertLevels, levelMap)
 				cfg.Checks = append(cfg.Checks, k)
 			}
 		}
 		cfg.RuleKeys = append(cfg.RuleKeys, sec)
 		cfg.SChecks[sec] = syntaxMap
+		cfg.SRuleToLevel[sec] = levelMap
 	}
 
 	return nil
@@ -3,11 +3,105 @@ package core
 import (
 	"regexp"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/errata-ai/vale/v2/internal/nlp"
 )
 
+// mapLoc locates an Alert using its Block's source mapping, if it has one.
+//
+// This is exact (unlike `FindLoc` and `assignLoc`, which have to search for
+// the alert's match in the source), but it only works for matches that start
+// in text copied verbatim from the source.
+func (f *File) mapLoc(blk nlp.Block, a *Alert) bool {
+	if a.Match == "" || len(blk.Segments) == 0 || a.Span[0] < 0 {
+		return false
+	}
+
+	// Alert spans are rune-based.
+	start := runeOffset(blk.Text, a.Span[0])
+	if start < 0 || !strings.HasPrefix(blk.Text[start:], a.Match) {
+		return false
+	}
+
+	src, size := blk.Source(start)
+	if src < 0 {
+		return false
+	}
+
+	m := f.SourceMap()
+	if size > len(a.Match) {
+		size = len(a.Match)
+	}
+
+	// The mapping should never be wrong, but we'd rather fall back to a
+	// search than report a bad location.
+	if !strings.HasPrefix(m.content[src:], blk.Text[start:start+size]) {
+		return false
+	} else if !isContiguous(blk, m.content, start, start+len(a.Match)) {
+		return false
+	}
+
+	line, col := m.Position(src)
+
+	a.Line = line
+	a.Span = []int{col, col + utf8.RuneCountInString(a.Match) - 1}
+
+	return true
+}
+
+// isContiguous reports whether the text between `start` and `end` is
+// faithful to the source.
+//
+// Converters may join text that's separated in the source (e.g., the end of
+// one list item and the start of a nested one), which could create matches
+// that don't actually exist.
+func isContiguous(blk nlp.Block, src string, start, end int) bool {
+	var prev *nlp.Segment
+	for i := range blk.Segments {
+		s := &blk.Segments[i]
+		if s.Start+s.Size <= start || s.Start >= end {
+			continue
+		}
+
+		if prev != nil {
+			from, to := prev.Source+prev.Size, s.Source
+			if to < from {
+				return false
+			}
+
+			gap := blk.Text[prev.Start+prev.Size : s.Start]
+			if !hasSpace(gap) && hasSpace(src[from:to]) {
+				return false
+			}
+		}
+
+		prev = s
+	}
+	return true
+}
+
+func hasSpace(s string) bool {
+	return strings.IndexFunc(s, unicode.IsSpace) >= 0
+}
+
+// runeOffset converts a rune index into a byte offset, returning -1 if it's
+// out of range.
+func runeOffset(s string, idx int) int {
+	count := 0
+	for i := range s {
+		if count == idx {
+			return i
+		}
+		count++
+	}
+	if count == idx {
+		return len(s)
+	}
+	return -1
+}
+
 // initialPosition calculates the position of a match (given by the location in
 // the reference document, `loc`) in the source document (`ctx`).
 func initialPosition(ctx, txt string, a Alert) (int, string) {
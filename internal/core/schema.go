@@ -0,0 +1,138 @@
+package core
+
+// optionDocs describes the `.vale.ini` keys for use in the configuration
+// schema.
+var optionDocs = map[string]string{
+	"StylesPath":     "The path to a directory containing styles.",
+	"MinAlertLevel":  "The minimum alert level to display.",
+	"IgnoredScopes":  "A list of inline HTML tags to ignore.",
+	"SkippedScopes":  "A list of block-level HTML tags to ignore.",
+	"IgnoredClasses": "A list of HTML classes to ignore.",
+	"WordTemplate":   "The template used to convert tokens into patterns.",
+	"DictionaryPath": "A directory to search for Hunspell dictionaries.",
+	"Vocab":          "A list of vocabularies to load from StylesPath.",
+	"NLPEndpoint":    "An external API to call for NLP-related tasks.",
+	"Packages":       "A list of packages to install with `vale sync`.",
+	"AlertLevels":    "A list of alert levels, ordered from least to most severe.",
+	"StrictRules":    "Reject rule definitions that contain unknown keys.",
+	"MessageLang":    "The language of rule messages (defaults to each file's Lang).",
+	"BasedOnStyles":  "A list of styles to apply.",
+	"IgnorePatterns": "Deprecated; use BlockIgnores instead.",
+	"BlockIgnores":   "A list of patterns for blocks of text to ignore.",
+	"TokenIgnores":   "A list of patterns for inline text to ignore.",
+	"Transform":      "An XSLT stylesheet to apply to XML files.",
+	"Lang":           "The language of the matched files.",
+}
+
+// ConfigSchema returns a JSON Schema describing the structure of a
+// `.vale.ini` file, with each INI section represented as an object.
+//
+// Level-valued options are limited to `levels` (see `Config.AlertLevels`).
+func ConfigSchema(levels []string) map[string]interface{} {
+	core := map[string]interface{}{
+		"Packages": optionSchema("Packages", levels),
+	}
+	for k := range coreOpts {
+		core[k] = optionSchema(k, levels)
+	}
+
+	global := map[string]interface{}{}
+	for k := range globalOpts {
+		global[k] = optionSchema(k, levels)
+	}
+
+	syntax := map[string]interface{}{}
+	for k := range syntaxOpts {
+		syntax[k] = optionSchema(k, levels)
+	}
+
+	core["*"] = sectionSchema(global, true, levels)
+	core["formats"] = map[string]interface{}{
+		"description":          "A map of unknown extensions to known formats.",
+		"type":                 "object",
+		"additionalProperties": map[string]interface{}{"type": "string"},
+	}
+	core["asciidoctor"] = map[string]interface{}{
+		"description":          "A map of Asciidoctor attributes.",
+		"type":                 "object",
+		"additionalProperties": map[string]interface{}{"type": "string"},
+	}
+	core["tags"] = map[string]interface{}{
+		"description":          "A map of rule tags to the level of their rules.",
+		"type":                 "object",
+		"additionalProperties": map[string]interface{}{"enum": levels},
+	}
+
+	return map[string]interface{}{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"title":                "Vale configuration",
+		"type":                 "object",
+		"properties":           core,
+		"additionalProperties": sectionSchema(syntax, false, levels),
+	}
+}
+
+// listOptions are the keys whose values are comma-separated lists, which
+// may also be given as an array (e.g., by repeating the key).
+var listOptions = []string{
+	"AlertLevels",
+	"BasedOnStyles",
+	"BlockIgnores",
+	"IgnoredClasses",
+	"IgnoredScopes",
+	"IgnorePatterns",
+	"Packages",
+	"SkippedScopes",
+	"TokenIgnores",
+	"Vocab",
+}
+
+func optionSchema(key string, levels []string) map[string]interface{} {
+	schema := map[string]interface{}{}
+	if doc, ok := optionDocs[key]; ok {
+		schema["description"] = doc
+	}
+
+	switch {
+	case key == "MinAlertLevel":
+		schema["type"] = "string"
+		schema["enum"] = levels
+	case key == "StrictRules":
+		schema["type"] = "boolean"
+	case StringInSlice(key, listOptions):
+		schema["anyOf"] = []interface{}{
+			map[string]interface{}{"type": "string"},
+			map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string"},
+			},
+		}
+	default:
+		schema["type"] = "string"
+	}
+
+	return schema
+}
+
+func sectionSchema(props map[string]interface{}, global bool, available []string) map[string]interface{} {
+	levels := []string{"YES", "NO"}
+	levels = append(levels, available...)
+
+	desc := "Settings for files matching the section's glob pattern."
+	if global {
+		desc = "Settings for all files."
+	}
+
+	return map[string]interface{}{
+		"description": desc,
+		"type":        "object",
+		"properties":  props,
+		"patternProperties": map[string]interface{}{
+			`^[^.]+\.[^.]+$`: map[string]interface{}{
+				"description": "Enable, disable, or change the level of a rule.",
+				"enum":        levels,
+			},
+		},
+		"additionalProperties": false,
+	}
+}
@@ -0,0 +1,129 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// A SourceMap converts between the different ways of addressing a location
+// in a file's original content: byte offsets, (line, rune column) pairs, and
+// UTF-16 characters (as used by the Language Server Protocol).
+//
+// Lines and columns are 1-based; byte offsets and UTF-16 characters are
+// 0-based.
+type SourceMap struct {
+	content string
+	starts  []int // the byte offset of the start of each line
+}
+
+// NewSourceMap creates a SourceMap for the given content.
+func NewSourceMap(content string) *SourceMap {
+	starts := []int{0}
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &SourceMap{content: content, starts: starts}
+}
+
+// Content returns the content that the SourceMap was created from.
+func (m *SourceMap) Content() string {
+	return m.content
+}
+
+// Offset returns the byte offset of the given line and rune column.
+func (m *SourceMap) Offset(line, col int) int {
+	if line < 1 {
+		return 0
+	} else if line > len(m.starts) {
+		return len(m.content)
+	}
+
+	offset := m.starts[line-1]
+	for i := 1; i < col && offset < len(m.content); i++ {
+		if m.content[offset] == '\n' {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(m.content[offset:])
+		offset += size
+	}
+
+	return offset
+}
+
+// Position returns the line and rune column of the given byte offset.
+func (m *SourceMap) Position(offset int) (int, int) {
+	if offset > len(m.content) {
+		offset = len(m.content)
+	}
+
+	idx := sort.Search(len(m.starts), func(i int) bool {
+		return m.starts[i] > offset
+	})
+
+	start := m.starts[idx-1]
+	return idx, utf8.RuneCountInString(m.content[start:offset]) + 1
+}
+
+// UTF16Character returns the Language Server Protocol character of the given
+// byte offset: the number of UTF-16 code units that precede it on its line.
+//
+// Unlike our columns, this is 0-based.
+func (m *SourceMap) UTF16Character(offset int) int {
+	line, _ := m.Position(offset)
+
+	col := 0
+	for _, r := range m.content[m.starts[line-1]:offset] {
+		col += len(utf16.Encode([]rune{r}))
+	}
+
+	return col
+}
+
+// Extent returns the byte offset at which `match` ends, assuming that it
+// begins at `offset`, or -1 if the content at `offset` doesn't match.
+//
+// Runs of whitespace are considered equivalent, which allows us to find
+// matches that span multiple lines in the source (such as a sentence that
+// has been wrapped).
+func (m *SourceMap) Extent(offset int, match string) int {
+	if match == "" || offset < 0 || offset > len(m.content) {
+		return -1
+	}
+
+	src := m.content[offset:]
+	if strings.HasPrefix(src, match) {
+		return offset + len(match)
+	}
+
+	pos := 0
+	for _, r := range match {
+		if pos >= len(src) {
+			return -1
+		}
+
+		if unicode.IsSpace(r) {
+			s, size := utf8.DecodeRuneInString(src[pos:])
+			if !unicode.IsSpace(s) {
+				return -1
+			}
+			for unicode.IsSpace(s) && pos < len(src) {
+				pos += size
+				s, size = utf8.DecodeRuneInString(src[pos:])
+			}
+			continue
+		}
+
+		s, size := utf8.DecodeRuneInString(src[pos:])
+		if s != r {
+			return -1
+		}
+		pos += size
+	}
+
+	return offset + pos
+}
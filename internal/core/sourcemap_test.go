@@ -0,0 +1,74 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSourceMap(t *testing.T) {
+	m := NewSourceMap("A 😀 baz and foo\nbar here.\n")
+
+	start := m.Offset(1, 5)
+	if start != 7 {
+		t.Errorf("expected offset 7, got %d", start)
+	}
+
+	if line, col := m.Position(start); line != 1 || col != 5 {
+		t.Errorf("expected (1, 5), got (%d, %d)", line, col)
+	}
+
+	// The emoji is a surrogate pair, so it takes up two UTF-16 code units.
+	if char := m.UTF16Character(start); char != 5 {
+		t.Errorf("expected UTF-16 character 5, got %d", char)
+	}
+
+	begin := m.Offset(1, 13)
+	end := m.Extent(begin, "foo bar")
+	if line, col := m.Position(end - 1); line != 2 || col != 3 {
+		t.Errorf("expected (2, 3), got (%d, %d)", line, col)
+	}
+
+	if m.Extent(begin, "foo baz") != -1 {
+		t.Error("expected no match for 'foo baz'")
+	}
+}
+
+func TestSetRange(t *testing.T) {
+	f := File{Lines: strings.SplitAfter("A 😀 baz and foo\nbar é.\n", "\n")}
+
+	a := Alert{Match: "foo bar é", Line: 1, Span: []int{13, 21}}
+	f.setRange(&a)
+
+	if a.Span[0] != 13 || a.Span[1] != 21 {
+		t.Errorf("expected the span to be unchanged, got %v", a.Span)
+	}
+	if a.EndLine != 2 || a.EndColumn != 5 {
+		t.Errorf("expected the match to end at (2, 5), got (%d, %d)", a.EndLine, a.EndColumn)
+	}
+	if a.UTF16Characters[0] != 13 || a.UTF16Characters[1] != 5 {
+		t.Errorf("expected UTF-16 characters [13, 5), got %v", a.UTF16Characters)
+	}
+	if a.ByteSpan[0] != 15 || a.ByteSpan[1] != 25 {
+		t.Errorf("expected a byte span of [15, 25), got %v", a.ByteSpan)
+	}
+}
+
+func TestUTF16Characters(t *testing.T) {
+	f := File{Lines: strings.SplitAfter("😀foo 😀😀 bar\n", "\n")}
+
+	for _, tt := range []struct {
+		match string
+		span  []int
+		chars []int
+	}{
+		{"foo", []int{2, 4}, []int{2, 5}},
+		{"😀 bar", []int{7, 11}, []int{8, 14}},
+	} {
+		a := Alert{Match: tt.match, Line: 1, Span: tt.span}
+		f.setRange(&a)
+
+		if a.UTF16Characters[0] != tt.chars[0] || a.UTF16Characters[1] != tt.chars[1] {
+			t.Errorf("%q: expected UTF-16 characters %v, got %v", tt.match, tt.chars, a.UTF16Characters)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"strings"
+)
+
+// A Suppression is an in-text comment that disables one or more rules.
+//
+// There are two kinds of suppressions: those that change the state of the
+// rules for the following blocks of text (`vale off` and `vale Style.Rule =
+// NO`) and those that apply to a range of lines (`vale-ignore-next-line` and
+// `vale-ignore-start`).
+type Suppression struct {
+	Directive string   // e.g., `vale-ignore-next-line Style.Rule`
+	Rules     []string // the disabled rules (all rules if empty)
+	Reason    string   // the text following `--`, if any
+	Line      int      // the line of the comment (0 if unknown)
+	EndLine   int      // the line of the closing comment (0 if unclosed)
+	Used      bool     // did it suppress any alerts?
+
+	// The range of lines covered by a line-based suppression; `last` is 0
+	// for a range that hasn't been closed.
+	first, last int
+}
+
+// splitReason separates a comment into its directive and the reason given
+// after `--`.
+func splitReason(comment string) (string, string) {
+	directive, reason, _ := strings.Cut(comment, "--")
+	return strings.TrimSpace(directive), strings.TrimSpace(reason)
+}
+
+// parseRules splits a space- or comma-delimited list of rules.
+func parseRules(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+// matchRule reports whether the rule `name` is covered by `pattern`, which
+// may be a style wildcard such as `Microsoft.*`.
+func matchRule(pattern, name string) bool {
+	if strings.HasSuffix(pattern, ".*") {
+		return strings.HasPrefix(name, strings.TrimSuffix(pattern, "*"))
+	}
+	return name == pattern || strings.HasPrefix(name, pattern+".")
+}
+
+// covers reports whether the suppression applies to the given alert.
+func (s *Suppression) covers(a Alert) bool {
+	if s.first == 0 || a.Line < s.first || (s.last > 0 && a.Line > s.last) {
+		return false
+	} else if len(s.Rules) == 0 {
+		return true
+	}
+
+	for _, rule := range s.Rules {
+		if matchRule(rule, a.Check) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// suppress adds a new suppression, using `key` to track its state.
+func (f *File) suppress(key string, s *Suppression) {
+	f.Suppressions = append(f.Suppressions, s)
+	if key != "" {
+		f.active[key] = s
+	}
+}
+
+// release closes the suppression tracked by `key`.
+//
+// If we don't know the line of the closing comment (`line` is 0), we can't
+// tell where a line-based suppression ends, so it no longer covers any
+// lines.
+func (f *File) release(key string, line int) {
+	if s, ok := f.active[key]; ok {
+		s.EndLine = line
+		if line < 1 {
+			s.first = 0
+		} else if s.first > 0 {
+			s.last = line - 1
+		}
+		delete(f.active, key)
+	}
+}
+
+// isSuppressed reports whether the given alert falls within a line-based
+// suppression.
+func (f *File) isSuppressed(a Alert) bool {
+	for _, s := range f.Suppressions {
+		if s.covers(a) {
+			s.Used = true
+			return true
+		}
+	}
+	return false
+}
+
+// Suppressor returns the comment that has disabled `check` for the current
+// block of text, if any.
+func (f *File) Suppressor(check string) *Suppression {
+	if f.Comments["off"] {
+		return f.active["off"]
+	}
+	return f.active[f.commentKey(check)]
+}
+
+// commentKey returns the key in `f.Comments` that controls `check`.
+func (f *File) commentKey(check string) string {
+	if _, ok := f.Comments[check]; ok {
+		return check
+	}
+	return strings.Split(check, ".")[0] + ".*"
+}
+
+// unusedRule is the name under which we report unused suppressions.
+//
+// Its level (by default, "warning") can be changed like that of any other
+// rule -- e.g., `Vale.UnusedSuppression = error` -- and it can be turned off
+// for a section with `Vale.UnusedSuppression = NO`.
+const unusedRule = "Vale.UnusedSuppression"
+
+// AddUnusedSuppressions reports every suppression that didn't suppress any
+// alerts, unless the level of `unusedRule` is below the file's minimum.
+func (f *File) AddUnusedSuppressions(config *Config) {
+	enabled, found := f.Checks[unusedRule]
+	if !found {
+		enabled, found = config.GChecks[unusedRule]
+	}
+	if found && !enabled {
+		return
+	}
+
+	level := "warning"
+	if changed, found := config.RuleToLevel[unusedRule]; found {
+		level = changed
+	}
+	level = f.RuleLevel(unusedRule, level)
+
+	if config.LevelToInt[level] < f.MinLevel {
+		return
+	}
+
+	for _, s := range f.Suppressions {
+		if s.Used {
+			continue
+		}
+
+		line := s.Line
+		if line < 1 {
+			line = 1
+		}
+
+		f.Alerts = append(f.Alerts, Alert{
+			Check:    unusedRule,
+			Severity: level,
+			Line:     line,
+			Span:     []int{1, 1},
+			Message:  "'" + s.Directive + "' doesn't suppress any alerts.",
+		})
+	}
+}
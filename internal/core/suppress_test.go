@@ -0,0 +1,133 @@
+package core
+
+import "testing"
+
+func TestSuppressions(t *testing.T) {
+	f := File{
+		Comments: make(map[string]bool),
+		active:   make(map[string]*Suppression),
+	}
+
+	f.UpdateComments("vale-ignore-next-line Vale.Spelling, Vale.Terms -- names", 2)
+	f.UpdateComments("vale-ignore-start", 5)
+	f.UpdateComments("vale-ignore-end", 8)
+	f.UpdateComments("vale Microsoft.* = NO", 10)
+
+	cases := []struct {
+		check      string
+		line       int
+		suppressed bool
+	}{
+		{"Vale.Spelling", 2, false},
+		{"Vale.Terms", 3, true},
+		{"Vale.Repetition", 3, false},
+		{"Vale.Spelling", 4, false},
+		{"Vale.Spelling", 6, true},
+		{"Vale.Spelling", 7, true},
+		{"Vale.Spelling", 8, false},
+	}
+	for _, tt := range cases {
+		a := Alert{Check: tt.check, Line: tt.line}
+		if got := f.isSuppressed(a); got != tt.suppressed {
+			t.Errorf("%s on line %d: expected %v, got %v", tt.check, tt.line, tt.suppressed, got)
+		}
+	}
+
+	if s := f.Suppressions[0]; s.Reason != "names" || len(s.Rules) != 2 {
+		t.Errorf("unexpected suppression: %+v", s)
+	}
+
+	if !f.QueryComments("Microsoft.Foo") || f.QueryComments("Vale.Spelling") {
+		t.Error("expected 'Microsoft.*' to only disable Microsoft rules")
+	} else if f.Suppressor("Microsoft.Foo") != f.Suppressions[2] {
+		t.Error("expected 'Microsoft.Foo' to be disabled by 'vale Microsoft.* = NO'")
+	}
+
+	f.UpdateComments("vale Microsoft.Foo = YES", 12)
+	if f.QueryComments("Microsoft.Foo") || !f.QueryComments("Microsoft.Bar") {
+		t.Error("expected 'Microsoft.Foo' to override 'Microsoft.*'")
+	}
+}
+
+func TestUnknownSuppressionLines(t *testing.T) {
+	f := File{
+		Comments: make(map[string]bool),
+		active:   make(map[string]*Suppression),
+	}
+
+	// NOTE: A line of 0 means that we couldn't find the comment.
+	f.UpdateComments("vale-ignore-next-line", 0)
+	f.UpdateComments("vale-ignore-start Vale.Terms", 0)
+	f.UpdateComments("vale-ignore-end", 5)
+	f.UpdateComments("vale-ignore-start Vale.Repetition", 7)
+	f.UpdateComments("vale-ignore-end", 0)
+
+	for _, a := range []Alert{
+		{Check: "Vale.Spelling", Line: 1},
+		{Check: "Vale.Terms", Line: 3},
+		{Check: "Vale.Repetition", Line: 9},
+	} {
+		if f.isSuppressed(a) {
+			t.Errorf("%s on line %d: expected no suppression", a.Check, a.Line)
+		}
+	}
+
+	if len(f.Suppressions) != 3 {
+		t.Errorf("expected 3 suppressions, got %d", len(f.Suppressions))
+	}
+}
+
+func TestUnusedSuppressionLevels(t *testing.T) {
+	cfg, err := NewConfig(&CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	} else if err = cfg.SetAlertLevels([]string{"suggestion", "warning", "error", "blocker"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		global, section string // the rule's level ("" if unchanged)
+		min             string // the file's minimum level
+		severity        string // "" if not reported
+	}{
+		{"", "", "suggestion", "warning"},
+		{"", "", "error", ""},
+		{"blocker", "", "error", "blocker"},
+		{"blocker", "suggestion", "warning", ""},
+		{"", "error", "warning", "error"},
+	}
+	for _, tt := range cases {
+		cfg.RuleToLevel = make(map[string]string)
+		if tt.global != "" {
+			cfg.RuleToLevel[unusedRule] = tt.global
+		}
+
+		f := File{
+			Comments: make(map[string]bool),
+			Levels:   make(map[string]string),
+			MinLevel: cfg.LevelToInt[tt.min],
+			active:   make(map[string]*Suppression),
+		}
+		if tt.section != "" {
+			f.Levels[unusedRule] = tt.section
+		}
+
+		f.UpdateComments("vale-ignore-next-line Foo.Bar", 1)
+		f.AddUnusedSuppressions(cfg)
+
+		if tt.severity == "" && len(f.Alerts) != 0 {
+			t.Errorf("%+v: expected no alerts, got %v", tt, f.Alerts)
+		} else if tt.severity != "" && (len(f.Alerts) != 1 || f.Alerts[0].Severity != tt.severity) {
+			t.Errorf("%+v: expected a '%s' alert, got %v", tt, tt.severity, f.Alerts)
+		}
+	}
+
+	cfg.RuleToLevel = make(map[string]string)
+	cfg.GChecks[unusedRule] = false
+
+	f := File{Comments: make(map[string]bool), active: make(map[string]*Suppression)}
+	f.UpdateComments("vale-ignore-next-line Foo.Bar", 1)
+	if f.AddUnusedSuppressions(cfg); len(f.Alerts) != 0 {
+		t.Errorf("expected 'Vale.UnusedSuppression = NO' to disable the alerts, got %v", f.Alerts)
+	}
+}
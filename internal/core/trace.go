@@ -0,0 +1,250 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/errata-ai/ini"
+)
+
+// A TraceEntry records where a single configuration value came from.
+type TraceEntry struct {
+	Field   string // the affected `Config` field -- e.g., `SBaseStyles[*.md]`
+	Section string // the INI section ("" for the core section)
+	Key     string // the INI key
+	Value   string // the value, as parsed
+	Origin  string // how the source was provided -- e.g., `--config`
+	Source  string // the path to the source file
+	Line    int    // the line within `Source` (0 if unknown)
+}
+
+// Trace reports the source file and line of every configuration value, in
+// the order in which they were applied.
+//
+// When a single-valued key (such as `MinAlertLevel`) has entries from
+// multiple sources, the first entry is the effective one; multi-valued keys
+// (such as `BasedOnStyles`) are merged across all of their entries.
+func (c *Config) Trace() []TraceEntry {
+	entries := append([]TraceEntry{}, c.trace...)
+
+	if StringInSlice(c.Flags.AlertLevel, c.AlertLevels) {
+		entries = append(entries, TraceEntry{
+			Field:  "MinAlertLevel",
+			Key:    "MinAlertLevel",
+			Value:  c.Flags.AlertLevel,
+			Origin: "--minAlertLevel"})
+	}
+
+	if c.Flags.Strict {
+		entries = append(entries, TraceEntry{
+			Field:  "StrictRules",
+			Key:    "StrictRules",
+			Value:  "YES",
+			Origin: "--strict"})
+	}
+
+	return entries
+}
+
+// loadSources merges the given configuration files, in order, into a single
+// INI file.
+//
+// Each file is appended to the merged file, so every value that it adds
+// (a new key or a new shadow of an existing one) is one that it contributed.
+func (c *Config) loadSources(paths []string, origin func(string) string) (*ini.File, error) {
+	var uCfg *ini.File
+	var err error
+
+	c.trace = []TraceEntry{}
+	for _, path := range paths {
+		seen := iniValues(uCfg)
+		if uCfg == nil {
+			uCfg, err = shadowLoad(path)
+		} else {
+			err = uCfg.Append(path)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		content, rerr := os.ReadFile(path)
+		if rerr != nil {
+			return nil, rerr
+		}
+		lines := strings.Split(string(content), "\n")
+
+		added := []TraceEntry{}
+		for _, sec := range uCfg.Sections() {
+			section := sec.Name()
+			if section == ini.DefaultSection {
+				section = ""
+			}
+			for _, key := range sec.Keys() {
+				for _, value := range key.ValueWithShadows() {
+					id := traceID(section, key.Name(), value)
+					if _, found := seen[id]; found {
+						continue
+					}
+					seen[id] = struct{}{}
+
+					added = append(added, TraceEntry{
+						Field:   traceField(section, key.Name(), value, c.AlertLevels),
+						Section: section,
+						Key:     key.Name(),
+						Value:   value,
+						Origin:  origin(path),
+						Source:  path,
+						Line:    keyLine(lines, section, key.Name(), value),
+					})
+				}
+			}
+		}
+
+		sort.SliceStable(added, func(i, j int) bool {
+			return added[i].Line < added[j].Line
+		})
+		c.trace = append(c.trace, added...)
+	}
+
+	return uCfg, nil
+}
+
+// traceVocab records that the given vocabulary file was loaded.
+func (c *Config) traceVocab(vocab, path string, accept bool) {
+	field := "RejectedTokens"
+	if accept {
+		field = "AcceptedTokens"
+	}
+	c.trace = append(c.trace, TraceEntry{
+		Field:  field,
+		Key:    "Vocab",
+		Value:  vocab,
+		Origin: "Vocab",
+		Source: path})
+}
+
+// iniValues returns the (section, key, value) triples in `file`.
+func iniValues(file *ini.File) map[string]struct{} {
+	values := make(map[string]struct{})
+	if file == nil {
+		return values
+	}
+
+	for _, sec := range file.Sections() {
+		section := sec.Name()
+		if section == ini.DefaultSection {
+			section = ""
+		}
+		for _, key := range sec.Keys() {
+			for _, value := range key.ValueWithShadows() {
+				values[traceID(section, key.Name(), value)] = struct{}{}
+			}
+		}
+	}
+
+	return values
+}
+
+func traceID(section, key, value string) string {
+	return section + "\x00" + key + "\x00" + value
+}
+
+// keyLine finds the (1-based) line on which `key` was assigned `value` in
+// `section`.
+//
+// The INI parser doesn't keep track of positions, so we look for the lines
+// that start an assignment to `key` and prefer the one whose text contains
+// (the first line of) the parsed value.
+func keyLine(lines []string, section, key, value string) int {
+	first := strings.SplitN(value, "\n", 2)[0]
+
+	current, found := "", 0
+	for i, line := range lines {
+		text := strings.TrimSpace(line)
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			current = strings.TrimSpace(text[1 : len(text)-1])
+			if current == ini.DefaultSection {
+				current = ""
+			}
+			continue
+		} else if current != section || !strings.HasPrefix(text, key) {
+			continue
+		}
+
+		rest := strings.TrimLeft(text[len(key):], " \t")
+		if !strings.HasPrefix(rest, "=") && !strings.HasPrefix(rest, ":") {
+			continue
+		} else if strings.Contains(rest[1:], first) {
+			return i + 1
+		} else if found == 0 {
+			found = i + 1
+		}
+	}
+
+	return found
+}
+
+// sourceOrigin describes how the configuration file at `path` was provided
+// when it's loaded as one of `--sources`.
+//
+// `ReadPipeline` re-loads the main configuration file after any
+// `.vale-config` files, so that file keeps the origin it was loaded with.
+func (c *Config) sourceOrigin(path string) string {
+	if filepath.Base(filepath.Dir(path)) == ".vale-config" {
+		return ".vale-config"
+	} else if main, _ := filepath.Abs(c.Flags.Path); c.origin != "" && path == main {
+		return c.origin
+	}
+	return "--sources"
+}
+
+// traceField maps an INI section and key to the `Config` field it populates.
+func traceField(section, key, value string, levels []string) string {
+	if section == "tags" {
+		return "TagToLevel[" + key + "]"
+	} else if strings.Contains(key, ".") && StringInSlice(value, levels) {
+		if section == "*" {
+			return "RuleToLevel[" + key + "]"
+		}
+		return "SRuleToLevel[" + section + "][" + key + "]"
+	} else if key == "MinAlertLevel" && section != "" && section != "DEFAULT" {
+		return "SMinAlertLevel[" + section + "]"
+	}
+
+	switch section {
+	case "", "DEFAULT":
+		return key
+	case "formats":
+		return "Formats[" + key + "]"
+	case "asciidoctor":
+		return "Asciidoctor[" + key + "]"
+	case "*":
+		switch key {
+		case "BasedOnStyles":
+			return "GBaseStyles"
+		case "IgnorePatterns", "BlockIgnores":
+			return "BlockIgnores[*]"
+		case "TokenIgnores":
+			return "TokenIgnores[*]"
+		case "Lang":
+			return "FormatToLang[*]"
+		}
+		return "GChecks[" + key + "]"
+	}
+
+	switch key {
+	case "BasedOnStyles":
+		return "SBaseStyles[" + section + "]"
+	case "IgnorePatterns", "BlockIgnores":
+		return "BlockIgnores[" + section + "]"
+	case "TokenIgnores":
+		return "TokenIgnores[" + section + "]"
+	case "Transform":
+		return "Stylesheets[" + section + "]"
+	case "Lang":
+		return "FormatToLang[" + section + "]"
+	}
+	return "SChecks[" + section + "][" + key + "]"
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTraceField(t *testing.T) {
+	cases := []struct {
+		section, key, value, field string
+	}{
+		{"", "MinAlertLevel", "warning", "MinAlertLevel"},
+		{"*", "BasedOnStyles", "Vale", "GBaseStyles"},
+		{"*", "Vale.Spelling", "NO", "GChecks[Vale.Spelling]"},
+		{"*", "Vale.Spelling", "error", "RuleToLevel[Vale.Spelling]"},
+		{"*.md", "Vale.Spelling", "error", "SRuleToLevel[*.md][Vale.Spelling]"},
+		{"drafts/*.md", "MinAlertLevel", "error", "SMinAlertLevel[drafts/*.md]"},
+		{"*.md", "BasedOnStyles", "Vale", "SBaseStyles[*.md]"},
+		{"*.md", "Vale.Terms", "YES", "SChecks[*.md][Vale.Terms]"},
+		{"formats", "mdx", "md", "Formats[mdx]"},
+		{"tags", "legal", "error", "TagToLevel[legal]"},
+	}
+	for _, tt := range cases {
+		if got := traceField(tt.section, tt.key, tt.value, builtinLevels); got != tt.field {
+			t.Errorf("(%q, %q) => %q != %q", tt.section, tt.key, got, tt.field)
+		}
+	}
+}
+
+func TestTrace(t *testing.T) {
+	root := t.TempDir()
+	styles := filepath.Join(root, "styles")
+
+	files := map[string]string{
+		"styles/.vale-config/0-pkg.ini": strings.Join([]string{
+			"MinAlertLevel = warning",
+			"",
+			"[*.md]",
+			"BasedOnStyles = Vale",
+			"TokenIgnores = \"\"\"(\\$+[^\\n$]+\\$+)",
+			"|(\\{[^}]+\\})\"\"\"",
+		}, "\n"),
+		"styles/Vocab/Base/accept.txt": "Vale\n",
+		".vale.ini": strings.Join([]string{
+			"StylesPath = styles",
+			"MinAlertLevel = error # overridden by the package",
+			"Vocab = Base",
+			"",
+			"[*.md]",
+			"BasedOnStyles = Vale",
+			"Vale.Spelling = NO",
+			"BasedOnStyles = write-good",
+		}, "\n"),
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	main := filepath.Join(root, ".vale.ini")
+	pkg := filepath.Join(styles, ".vale-config", "0-pkg.ini")
+
+	cfg, err := ReadPipeline("ini", &CLIFlags{Path: main}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []TraceEntry{
+		{"MinAlertLevel", "", "MinAlertLevel", "warning", ".vale-config", pkg, 1},
+		{"SBaseStyles[*.md]", "*.md", "BasedOnStyles", "Vale", ".vale-config", pkg, 4},
+		{"TokenIgnores[*.md]", "*.md", "TokenIgnores", "(\\$+[^\\n$]+\\$+)\n|(\\{[^}]+\\})", ".vale-config", pkg, 5},
+		{"StylesPath", "", "StylesPath", "styles", "--config", main, 1},
+		{"MinAlertLevel", "", "MinAlertLevel", "error", "--config", main, 2},
+		{"Vocab", "", "Vocab", "Base", "--config", main, 3},
+		{"SChecks[*.md][Vale.Spelling]", "*.md", "Vale.Spelling", "NO", "--config", main, 7},
+		{"SBaseStyles[*.md]", "*.md", "BasedOnStyles", "write-good", "--config", main, 8},
+		{"AcceptedTokens", "", "Vocab", "Base", "Vocab", filepath.Join(styles, "Vocab", "Base", "accept.txt"), 0},
+	}
+
+	entries := cfg.Trace()
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d: %+v", len(expected), len(entries), entries)
+	}
+	for i, entry := range entries {
+		if entry != expected[i] {
+			t.Errorf("%d: expected %+v, got %+v", i, expected[i], entry)
+		}
+	}
+
+	// The first value of a single-valued key is the effective one.
+	if cfg.MinAlertLevel != cfg.LevelToInt["warning"] {
+		t.Errorf("expected 'warning', got %d", cfg.MinAlertLevel)
+	}
+}
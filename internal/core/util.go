@@ -266,12 +266,12 @@ func mergeValues(shadows []string) []string {
 	return values
 }
 
-func validateLevel(key, val string, cfg *Config) bool {
-	options := []string{"YES", "suggestion", "warning", "error"}
+func validateLevel(key, val string, available []string, levels map[string]string) bool {
+	options := append([]string{"YES"}, available...)
 	if val == "NO" || !StringInSlice(val, options) {
 		return false
 	} else if val != "YES" {
-		cfg.RuleToLevel[key] = val
+		levels[key] = val
 	}
 	return true
 }
@@ -294,8 +294,10 @@ func loadVocab(root string, cfg *Config) error {
 		Callback: func(fp string, de *godirwalk.Dirent) error {
 			name := de.Name()
 			if name == "accept.txt" {
+				cfg.traceVocab(root, fp, true)
 				return cfg.AddWordListFile(fp, true)
 			} else if name == "reject.txt" {
+				cfg.traceVocab(root, fp, false)
 				return cfg.AddWordListFile(fp, false)
 			}
 			return nil
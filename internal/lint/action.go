@@ -56,6 +56,30 @@ func processAlert(alert core.Alert, cfg *core.Config) ([]string, error) {
 	return []string{}, errors.New("unknown action")
 }
 
+// actionEdits returns the edits implied by an alert's action.
+//
+// NOTE: We skip the `suggest` action since it requires loading (and running)
+// a spell checker for each alert.
+func actionEdits(alert core.Alert) []core.Edit {
+	edits := []core.Edit{}
+	if alert.Action.Name == "suggest" {
+		return edits
+	}
+
+	suggestions, err := processAlert(alert, nil)
+	if err != nil {
+		return edits
+	}
+
+	for _, s := range suggestions {
+		if s != alert.Match {
+			edits = append(edits, core.Edit{NewText: s})
+		}
+	}
+
+	return edits
+}
+
 func suggest(alert core.Alert, cfg *core.Config) ([]string, error) {
 	var suggestions = []string{}
 
@@ -66,6 +90,7 @@ func suggest(alert core.Alert, cfg *core.Config) ([]string, error) {
 	if err != nil {
 		return suggestions, err
 	}
+	defer mgr.Close()
 
 	if !strings.Contains(alert.Check, "Vale.") {
 		err = mgr.AddRuleFromFile(alert.Check, path)
@@ -92,15 +117,32 @@ func remove(_ core.Alert, _ *core.Config) ([]string, error) {
 
 func convert(alert core.Alert, _ *core.Config) ([]string, error) {
 	match := alert.Match
-	if alert.Action.Params[0] == "simple" {
+	if len(alert.Action.Params) == 0 {
+		return []string{}, errors.New("missing conversion")
+	} else if alert.Action.Params[0] == "simple" {
 		match = nlp.Simple(match)
 	}
 	return []string{match}, nil
 }
 
+// editParams is the number of parameters required by each `edit` action.
+var editParams = map[string]int{
+	"regex":      3,
+	"trim_right": 2,
+	"trim_left":  2,
+	"trim":       2,
+	"truncate":   2,
+	"split":      3,
+}
+
 func edit(alert core.Alert, _ *core.Config) ([]string, error) {
 	match := alert.Match
 
+	params := alert.Action.Params
+	if len(params) == 0 || len(params) < editParams[params[0]] {
+		return []string{}, errors.New("missing edit parameters")
+	}
+
 	switch name := alert.Action.Params[0]; name {
 	case "regex":
 		regex, err := regexp.Compile(alert.Action.Params[1])
@@ -121,7 +163,11 @@ func edit(alert core.Alert, _ *core.Config) ([]string, error) {
 		if err != nil {
 			return []string{}, err
 		}
-		match = strings.Split(match, alert.Action.Params[1])[index]
+		parts := strings.Split(match, alert.Action.Params[1])
+		if index < 0 || index >= len(parts) {
+			return []string{}, errors.New("split index out of range")
+		}
+		match = parts[index]
 	}
 
 	return []string{strings.TrimSpace(match)}, nil
@@ -0,0 +1,36 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+func TestActionEdits(t *testing.T) {
+	cases := []struct {
+		action   core.Action
+		match    string
+		expected []string
+	}{
+		{core.Action{Name: "edit", Params: []string{"truncate", " "}}, "the the", []string{"the"}},
+		{core.Action{Name: "replace", Params: []string{"JavaScript", "JS"}}, "javascript", []string{"JavaScript", "JS"}},
+		{core.Action{Name: "remove"}, "very", []string{""}},
+		{core.Action{Name: "replace", Params: []string{"very"}}, "very", []string{}},
+		{core.Action{Name: "suggest", Params: []string{"spellings"}}, "teh", []string{}},
+		{core.Action{Name: "convert"}, "Foo", []string{}},
+		{core.Action{Name: "edit", Params: []string{"split", " ", "4"}}, "a b", []string{}},
+		{core.Action{}, "foo", []string{}},
+	}
+
+	for _, c := range cases {
+		edits := actionEdits(core.Alert{Action: c.action, Match: c.match})
+		if len(edits) != len(c.expected) {
+			t.Fatalf("%v: expected %v, got %v", c.action, c.expected, edits)
+		}
+		for i, e := range edits {
+			if e.NewText != c.expected[i] {
+				t.Errorf("%v: expected %q, got %q", c.action, c.expected[i], e.NewText)
+			}
+		}
+	}
+}
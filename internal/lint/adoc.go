@@ -133,7 +133,7 @@ func (l *Linter) lintADoc(f *core.File) error {
 	})
 
 	f.Content = body
-	return l.lintHTMLTokens(f, []byte(html), 0)
+	return l.lintHTMLTokens(f, []byte(html), 0, nil)
 }
 
 func (l *Linter) startAdocServer(exe string, attrs map[string]string) error {
@@ -30,7 +30,12 @@ var tagToScope = map[string]string{
 	"figcaption": "text.figure.caption",
 }
 
-func (l *Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error { //nolint:unparam
+// lintHTMLTokens lints the HTML `raw`, which was converted from `f`.
+//
+// If the format's parser gives us source positions, `text` holds the file's
+// text (see `textStream`); otherwise, it's nil and we locate each token by
+// searching the source.
+func (l *Linter) lintHTMLTokens(f *core.File, raw []byte, offset int, text *textStream) error { //nolint:unparam
 	var class, parentClass, attr string
 	var inBlock, inline, skip, skipClass bool
 
@@ -50,6 +55,7 @@ func (l *Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error { //
 	}
 
 	walker := newWalker(f, raw, offset)
+	walker.text = text
 	for {
 		tokt, tok, txt := walker.walk()
 
@@ -65,7 +71,7 @@ func (l *Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error { //
 		} else if tokt == html.StartTagToken && (core.StringInSlice(txt, skipTags) || blockSkip) {
 			walker.setCls(txt, blockSkip)
 			inBlock = true
-			f.Metrics[txt]++
+			walker.count(f, txt)
 		} else if inBlock && (core.StringInSlice(txt, skipTags) || closed) {
 			inBlock = false
 			if closed {
@@ -82,7 +88,7 @@ func (l *Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error { //
 		} else if tokt == html.EndTagToken && core.StringInSlice(txt, inlineTags) {
 			walker.activeTag = ""
 		} else if tokt == html.CommentToken {
-			f.UpdateComments(txt)
+			f.UpdateComments(txt, walker.line(txt))
 		} else if tokt == html.TextToken {
 			skip = skip || shouldBeSkipped(walker.tagHistory, f.NormedExt)
 			// NOTE: We used to create a "temporary" context here to support
@@ -101,7 +107,9 @@ func (l *Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error { //
 			walker.append(txt)
 			if !inBlock && txt != "" {
 				skipClass = checkClasses(parentClass, skipClasses)
+				token := txt
 				txt, skip = clean(txt, attr, skip || skipClass, inline)
+				walker.record(buf.Len(), token, txt)
 				buf.WriteString(txt)
 			}
 		}
@@ -127,31 +135,107 @@ func (l *Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error { //
 		}
 	}
 
+	if err := l.lintOutline(f, walker); err != nil {
+		return err
+	}
+
+	if err := l.lintSections(f, walker); err != nil {
+		return err
+	}
+
 	return l.lintSizedScopes(f)
 }
 
+// lintOutline runs all rules with `extends: structure` on the file's
+// headings, which we give one per line.
+func (l *Linter) lintOutline(f *core.File, state *walker) error {
+	f.Outline = state.outline
+	if len(f.Outline) == 0 {
+		return nil
+	}
+
+	var text strings.Builder
+
+	segments := []nlp.Segment{}
+	for i, h := range state.headings {
+		if i > 0 {
+			text.WriteString("\n")
+		}
+		for _, s := range h.Segments {
+			s.Start += text.Len()
+			segments = append(segments, s)
+		}
+		// NOTE: A heading's text may span multiple lines (e.g., a Setext
+		// heading), so we replace its newlines to keep one heading per line.
+		text.WriteString(strings.ReplaceAll(h.Text, "\n", " "))
+	}
+
+	blk := nlp.NewBlock("", text.String(), "outline"+f.RealExt)
+	blk.Segments = segments
+
+	return l.lintBlock(f, blk, state.lines, 0, false)
+}
+
+// lintSections runs all rules with `scope: section` (i.e., `readability`
+// and `metric`) on the summarized content under each heading.
+func (l *Linter) lintSections(f *core.File, state *walker) error {
+	if !l.Manager.HasScope("section") {
+		return nil
+	}
+
+	for _, s := range state.sections {
+		if len(s.segments) == 0 {
+			continue
+		}
+
+		line, _ := f.SourceMap().Position(s.segments[0].Source)
+		f.Sections = append(f.Sections, core.Section{Line: line, Metrics: s.metrics})
+
+		blk := nlp.NewLinedBlock(f.Content, s.text.String(), "section"+f.RealExt, line-1, nil)
+		blk.Segments = s.segments
+
+		if err := l.lintBlock(f, blk, state.lines, 0, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (l *Linter) lintScope(f *core.File, state *walker, txt string) error {
 	for _, tag := range state.tagHistory {
 		scope, match := tagToScope[tag]
 		if (match && !core.StringInSlice(tag, inlineTags)) || heading.MatchString(tag) {
-			if scope == "text.blockquote" || scope == "text.list" {
+			summarized := scope == "text.blockquote" || scope == "text.list"
+			if summarized {
 				f.Summary.WriteString(txt + "\n\n")
 			}
 
 			if !match {
 				scope = "text.heading." + tag
 			}
-			f.Metrics[strings.TrimPrefix(scope, "text.")]++
 
 			txt = strings.TrimLeft(txt, " ")
-			b := state.block(txt, scope+f.RealExt)
+			b := state.textBlock(txt, scope+f.RealExt)
+			if !match {
+				state.addHeading(b, int(tag[1]-'0'))
+				// NOTE: Rules can tell which section they're in by the
+				// headings that we've seen so far (see `conditional`).
+				f.Outline = state.outline
+			} else if summarized {
+				state.summarize(b)
+			}
+			state.count(f, strings.TrimPrefix(scope, "text."))
+
 			return l.lintBlock(f, b, state.lines, 0, false)
 		}
 	}
 
 	f.Summary.WriteString(txt + "\n\n")
 
-	b := state.block(txt, "txt")
+	b := state.textBlock(txt, "txt")
+	state.summarize(b)
+
 	return l.lintProse(f, b, state.lines)
 }
 
@@ -19,6 +19,8 @@ func (l *Linter) lintCode(f *core.File) error {
 	var block bytes.Buffer
 
 	lines := 0
+	// The byte offsets of the current line and block comment in `f.Content`.
+	offset, start := 0, 0
 	comments := core.CommentsByNormedExt[f.NormedExt]
 	if len(comments) == 0 {
 		return nil
@@ -43,6 +45,7 @@ func (l *Linter) lintCode(f *core.File) error {
 		line = core.Sanitize(scanner.Text() + "\n")
 		lnLength = len(line)
 		lines++
+		offset += lnLength
 		if inBlock {
 			// We're in a block comment.
 			if match = blockEnd.FindString(line); len(match) > 0 {
@@ -52,6 +55,7 @@ func (l *Linter) lintCode(f *core.File) error {
 
 				b := nlp.NewBlock(
 					txt, txt, fmt.Sprintf(scope, "text.comment.block"))
+				b.Segments = []nlp.Segment{{Source: start, Size: len(txt)}}
 				if !(skipAll || skipBlock) {
 					if err := l.lintBlock(f, b, lines+1, 0, true); err != nil {
 						return err
@@ -71,6 +75,9 @@ func (l *Linter) lintCode(f *core.File) error {
 
 			b := nlp.NewBlock(
 				match, match, fmt.Sprintf(scope, "text.comment.line"))
+			b.Segments = []nlp.Segment{{
+				Source: offset - lnLength + strings.Index(line, match),
+				Size:   len(match)}}
 			if !(skipAll || skipInline) {
 				if err := l.lintBlock(f, b, lines, padding-1, true); err != nil {
 					return err
@@ -78,6 +85,7 @@ func (l *Linter) lintCode(f *core.File) error {
 			}
 		} else if match = blockStart.FindString(line); len(match) > 0 && !ignore {
 			// We've found the start of a block comment.
+			start = offset - lnLength
 			block.WriteString(line)
 			inBlock = true
 		} else if match = blockEnd.FindString(line); len(match) > 0 {
@@ -69,5 +69,5 @@ func (l Linter) lintDITA(file *core.File) error {
 		data = append(data[:head1], data[head2:]...)
 	}
 
-	return l.lintHTMLTokens(file, data, 0)
+	return l.lintHTMLTokens(file, data, 0, nil)
 }
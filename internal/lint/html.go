@@ -27,7 +27,7 @@ func (l *Linter) lintHTML(f *core.File) error {
 	if l.Manager.Config.Flags.Built != "" {
 		return l.lintTxtToHTML(f)
 	}
-	return l.lintHTMLTokens(f, []byte(f.Content), 0)
+	return l.lintHTMLTokens(f, []byte(f.Content), 0, nil)
 }
 
 func (l *Linter) applyPatterns(content, block, inline, ext string) (string, error) {
@@ -128,7 +128,7 @@ func (l *Linter) lintTxtToHTML(f *core.File) error {
 	if err != nil {
 		return core.NewE100(f.Path, err)
 	}
-	return l.lintHTMLTokens(f, html, 0)
+	return l.lintHTMLTokens(f, html, 0, nil)
 }
 
 func ping(domain string) error {
@@ -48,6 +48,9 @@ func NewLinter(cfg *core.Config) (*Linter, error) {
 // LintString src according to its format.
 func (l *Linter) LintString(src string) ([]*core.File, error) {
 	linted := l.lintFile(src)
+	if linted.err == nil {
+		l.lintProject([]*core.File{linted.file})
+	}
 	return []*core.File{linted.file}, linted.err
 }
 
@@ -98,6 +101,7 @@ func (l *Linter) Lint(input []string, pat string) ([]*core.File, error) {
 		return linted, err
 	}
 
+	l.lintProject(linted)
 	return linted, nil
 }
 
@@ -210,6 +214,12 @@ func (l *Linter) lintFile(src string) lintResult {
 		err = l.lintBlock(file, raw, len(file.Lines), 0, true)
 	}
 
+	if err == nil && l.Manager.Config.Flags.ReportUnused {
+		file.AddUnusedSuppressions(l.Manager.Config)
+	}
+
+	file.SetFingerprints(l.Manager.Config.Root)
+
 	return lintResult{file, err}
 }
 
@@ -238,54 +248,98 @@ func (l *Linter) lintProse(f *core.File, blk nlp.Block, lines int) error {
 
 func (l *Linter) lintTxt(f *core.File) error {
 	block := nlp.NewBlock("", f.Content, "text"+f.RealExt)
+	block.Segments = []nlp.Segment{{Size: len(f.Content)}}
 	return l.lintProse(f, block, len(f.Lines))
 }
 
 func (l *Linter) lintLines(f *core.File) error {
 	block := nlp.NewBlock("", f.Content, "text"+f.RealExt)
+	block.Segments = []nlp.Segment{{Size: len(f.Content)}}
 	return l.lintBlock(f, block, len(f.Lines), 0, true)
 }
 
 func (l *Linter) lintBlock(f *core.File, blk nlp.Block, lines, pad int, lookup bool) error {
 	f.ChkToCtx = make(map[string]string)
+
+	skips := l.Manager.Skips(blk)
 	for name, chk := range l.Manager.Rules() {
-		if !l.shouldRun(name, f, chk, blk) {
+		if !l.shouldRun(name, f, chk, blk) || skips(name) {
 			continue
 		}
 
 		info := chk.Fields()
+		level := f.RuleLevel(ruleName(name), info.Level)
+		project := check.IsProject(chk)
+
+		var sup *core.Suppression
+		if f.QueryComments(ruleName(name)) {
+			// It has been disabled via an in-text comment.
+			sup = f.Suppressor(ruleName(name))
+			if !l.isTracked(sup) {
+				continue
+			}
+		}
 
 		alerts, err := chk.Run(blk, f)
 		if err != nil {
 			return err
+		} else if sup != nil {
+			// We only ran the rule to see if the comment was needed.
+			sup.Used = len(alerts) > 0
+			continue
 		}
 		for i := range alerts {
-			core.FormatAlert(&alerts[i], info.Limit, info.Level, name)
+			if alerts[i].Severity == info.Level {
+				// The rule's level may have been changed for this file.
+				alerts[i].Severity = level
+			}
+			alerts[i].Tags = info.Tags
+			if len(alerts[i].Suggestions) == 0 {
+				alerts[i].Suggestions = actionEdits(alerts[i])
+			}
+			lang := l.messageLang(f)
+			check.Localize(&alerts[i], info, lang)
+			// NOTE: A template that fails to render for this alert (e.g.,
+			// one that indexes a missing suggestion) is left as is rather
+			// than stopping the run.
+			_ = check.RenderMessages(&alerts[i], info, lang, f.Path)
+			core.FormatAlert(&alerts[i], info.Limit, level, name)
+
+			n := len(f.Alerts)
 			f.AddAlert(alerts[i], blk, lines, pad, lookup)
+			if project && len(f.Alerts) > n {
+				// We can't report this until we've seen every file.
+				f.Candidates = append(f.Candidates, f.Alerts[n:]...)
+				f.Alerts = f.Alerts[:n]
+			}
 		}
 	}
 
 	return nil
 }
 
+// messageLang returns the language in which to report alerts for `f`.
+func (l *Linter) messageLang(f *core.File) string {
+	if l.Manager.Config.MessageLang != "" {
+		return l.Manager.Config.MessageLang
+	}
+	return f.NLP.Lang
+}
+
+// isTracked reports whether we need to run a rule that's been disabled by
+// the in-text comment `s` in order to find out if the comment is needed.
+func (l *Linter) isTracked(s *core.Suppression) bool {
+	return s != nil && !s.Used && l.Manager.Config.Flags.ReportUnused
+}
+
 func (l *Linter) shouldRun(name string, f *core.File, chk check.Rule, blk nlp.Block) bool {
-	min := l.Manager.Config.MinAlertLevel
 	run := false
 
 	details := chk.Fields()
-	if strings.Count(name, ".") > 1 {
-		// NOTE: This fixes the loading issue with consistency checks.
-		//
-		// See #129.
-		list := strings.Split(name, ".")
-		name = strings.Join([]string{list[0], list[1]}, ".")
-	}
+	name = ruleName(name)
 
 	chkScope := check.NewScope(details.Scope)
-	if f.QueryComments(name) { //nolint:gocritic
-		// It has been disabled via an in-text comment.
-		return false
-	} else if core.LevelToInt[details.Level] < min {
+	if l.Manager.Config.LevelToInt[f.RuleLevel(name, details.Level)] < f.MinLevel { //nolint:gocritic
 		return false
 	} else if !chkScope.Matches(blk) {
 		return false
@@ -315,6 +369,18 @@ func (l *Linter) shouldRun(name string, f *core.File, chk check.Rule, blk nlp.Bl
 	return true
 }
 
+// ruleName returns the `Style.Rule` form of the given check name.
+func ruleName(name string) string {
+	if strings.Count(name, ".") > 1 {
+		// NOTE: This fixes the loading issue with consistency checks.
+		//
+		// See #129.
+		list := strings.Split(name, ".")
+		name = strings.Join([]string{list[0], list[1]}, ".")
+	}
+	return name
+}
+
 // setup handles any necessary building, compiling, or pre-processing.
 func (l *Linter) setup() error {
 	return nil
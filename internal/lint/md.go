@@ -2,13 +2,17 @@ package lint
 
 import (
 	"bytes"
+	"strconv"
 	"strings"
 
 	"github.com/errata-ai/vale/v2/internal/core"
 	"github.com/jdkato/regexp"
 	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
 	grh "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
 )
 
 // Markdown configuration.
@@ -34,10 +38,19 @@ func (l Linter) lintMarkdown(f *core.File) error {
 		return err
 	}
 
-	if err = goldMd.Convert([]byte(s), &buf); err != nil {
+	src := []byte(s)
+
+	doc := goldMd.Parser().Parse(text.NewReader(src))
+	if err = goldMd.Renderer().Render(&buf, src, doc); err != nil {
 		return core.NewE100(f.Path, err)
 	}
 
+	stream := markdownText(doc, src)
+	if !stream.realign(s, f.SourceMap().Content()) {
+		// We fall back to searching the source for each token.
+		stream = nil
+	}
+
 	// NOTE: This is required to avoid finding matches inside info strings. For
 	// example, if we're looking for 'json' we many incorrectly report the
 	// location as being in an infostring like '```json'.
@@ -57,5 +70,81 @@ func (l Linter) lintMarkdown(f *core.File) error {
 	})
 
 	f.Content = body
-	return l.lintHTMLTokens(f, buf.Bytes(), 0)
+	return l.lintHTMLTokens(f, buf.Bytes(), 0, stream)
+}
+
+// markdownText collects the text of a Markdown document in the order that
+// it's rendered, using the source positions recorded on its AST.
+func markdownText(doc ast.Node, src []byte) *textStream {
+	stream := &textStream{}
+
+	addLines := func(lines *text.Segments, raw bool) {
+		for i := 0; i < lines.Len(); i++ {
+			seg := lines.At(i)
+			stream.add(strings.Repeat(" ", seg.Padding), -1)
+			if raw {
+				stream.addHTML(string(src[seg.Start:seg.Stop]), seg.Start)
+			} else {
+				stream.add(string(src[seg.Start:seg.Stop]), seg.Start)
+			}
+		}
+	}
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *ast.Text:
+			if n.IsRaw() {
+				stream.add(string(n.Segment.Value(src)), n.Segment.Start)
+			} else {
+				stream.addText(string(n.Segment.Value(src)), n.Segment.Start)
+			}
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				stream.add("\n", -1)
+			}
+		case *ast.String:
+			stream.add(string(n.Value), -1)
+		case *ast.CodeSpan:
+			// NOTE: Line endings in code spans are rendered as spaces.
+			for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+				seg := c.(*ast.Text).Segment
+				value := string(seg.Value(src))
+				if strings.HasSuffix(value, "\n") {
+					stream.add(value[:len(value)-1], seg.Start)
+					stream.add(" ", -1)
+				} else {
+					stream.add(value, seg.Start)
+				}
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.AutoLink:
+			// NOTE: The label is a slice of `src`, so its capacity gives us
+			// its position.
+			label := n.Label(src)
+			stream.add(string(label), cap(src)-cap(label))
+		case *ast.Image:
+			// The alt text is rendered as an attribute.
+			return ast.WalkSkipChildren, nil
+		case *ast.RawHTML:
+			addLines(n.Segments, true)
+		case *ast.HTMLBlock:
+			addLines(n.Lines(), true)
+			if n.HasClosure() {
+				stream.addHTML(string(n.ClosureLine.Value(src)), n.ClosureLine.Start)
+			}
+		case *ast.CodeBlock, *ast.FencedCodeBlock:
+			addLines(n.Lines(), false)
+		case *east.FootnoteLink:
+			stream.add(strconv.Itoa(n.Index), -1)
+		case *east.FootnoteBacklink:
+			stream.add("\u21a9\ufe0e", -1)
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	return stream
 }
@@ -55,5 +55,5 @@ func (l Linter) lintOrg(f *core.File) error {
 	}
 
 	f.Content = body
-	return l.lintHTMLTokens(f, []byte(html), 0)
+	return l.lintHTMLTokens(f, []byte(html), 0, nil)
 }
@@ -0,0 +1,61 @@
+package lint
+
+import (
+	"sort"
+
+	"github.com/errata-ai/vale/v2/internal/check"
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+type candidate struct {
+	file  *core.File
+	alert core.Alert
+}
+
+// lintProject reports the alerts of project-wide rules (see
+// `check.ProjectRule`), which can only be decided once every file has been
+// linted.
+func (l *Linter) lintProject(files []*core.File) {
+	sorted := make([]*core.File, len(files))
+	copy(sorted, files)
+	sort.Sort(core.ByName(sorted))
+
+	byRule := map[string][]candidate{}
+	for _, f := range sorted {
+		sort.Stable(core.ByPosition(f.Candidates))
+		for _, a := range f.Candidates {
+			byRule[a.Check] = append(byRule[a.Check], candidate{file: f, alert: a})
+		}
+		f.Candidates = nil
+	}
+
+	if len(byRule) == 0 {
+		return
+	}
+
+	changed := map[*core.File]bool{}
+	for name, chk := range l.Manager.Rules() {
+		rule, ok := chk.(check.ProjectRule)
+		if !ok || len(byRule[ruleName(name)]) == 0 {
+			continue
+		}
+
+		found := byRule[ruleName(name)]
+
+		alerts := make([]core.Alert, len(found))
+		for i, c := range found {
+			alerts[i] = c.alert
+		}
+
+		for i, keep := range rule.Select(alerts) {
+			if keep {
+				found[i].file.Alerts = append(found[i].file.Alerts, found[i].alert)
+				changed[found[i].file] = true
+			}
+		}
+	}
+
+	for f := range changed {
+		f.SetFingerprints(l.Manager.Config.Root)
+	}
+}
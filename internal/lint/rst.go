@@ -164,7 +164,7 @@ func (l *Linter) lintRST(f *core.File) error {
 		}
 	}
 
-	return l.lintHTMLTokens(f, []byte(html), 0)
+	return l.lintHTMLTokens(f, []byte(html), 0, nil)
 }
 
 func callRst(text, lib, exe string) (string, error) {
@@ -0,0 +1,241 @@
+package lint
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/errata-ai/vale/v2/internal/nlp"
+	"github.com/jdkato/regexp"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/net/html"
+)
+
+// maxEdits is the most bytes that `alignContent` allows the edited content
+// to differ by before giving up.
+const maxEdits = 256
+
+// entityRef matches an HTML entity or numeric character reference.
+var entityRef = regexp.MustCompile(`^&(?:#[xX][0-9a-fA-F]{1,6}|#[0-9]{1,7}|[A-Za-z][A-Za-z0-9]*);`)
+
+// A textStream is the text that a format's parser found in a file, in
+// document order, along with where each piece of it came from.
+//
+// It lets the walker map each text token to its source location using the
+// parser's positions rather than by searching the source for the token
+// (which can, e.g., match inside a link's URL).
+type textStream struct {
+	text     strings.Builder
+	segments []nlp.Segment // source offsets of the text copied verbatim
+	cursor   int           // the end of the last token we located
+
+	// htmlEnd is what we're waiting for to end the current HTML tag or
+	// comment (see `addHTML`), if anything.
+	htmlEnd string
+}
+
+// add appends `s` to the stream: `source` is its offset in the source, or -1
+// if it isn't a verbatim copy (e.g., a resolved entity).
+func (t *textStream) add(s string, source int) {
+	if s == "" {
+		return
+	} else if source >= 0 {
+		n := len(t.segments)
+		if n > 0 && t.segments[n-1].Start+t.segments[n-1].Size == t.text.Len() &&
+			t.segments[n-1].Source+t.segments[n-1].Size == source {
+			t.segments[n-1].Size += len(s)
+		} else {
+			t.segments = append(t.segments, nlp.Segment{
+				Start: t.text.Len(), Source: source, Size: len(s)})
+		}
+	}
+	t.text.WriteString(s)
+}
+
+// addText appends text that may contain backslash escapes and character
+// references, which are resolved the same way the Markdown renderer does.
+func (t *textStream) addText(s string, source int) {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && util.IsPunct(s[i+1]) {
+			t.add(s[n:i], source+n)
+			// The escaped character is kept as-is.
+			n = i + 1
+			i++
+		} else if s[i] == '&' {
+			n, i = t.addRef(s, source, n, i)
+		}
+	}
+	t.add(s[n:], source+n)
+}
+
+// addHTML appends the text in the raw HTML `s`, skipping its tags and
+// comments (which may continue across calls).
+func (t *textStream) addHTML(s string, source int) {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if t.htmlEnd != "" {
+			if strings.HasPrefix(s[i:], t.htmlEnd) {
+				i += len(t.htmlEnd) - 1
+				n = i + 1
+				t.htmlEnd = ""
+			}
+		} else if s[i] == '<' {
+			t.add(s[n:i], source+n)
+			t.htmlEnd = ">"
+			if strings.HasPrefix(s[i:], "<!--") {
+				t.htmlEnd = "-->"
+			}
+		} else if s[i] == '&' {
+			n, i = t.addRef(s, source, n, i)
+		}
+	}
+	if t.htmlEnd == "" {
+		t.add(s[n:], source+n)
+	}
+}
+
+// addRef resolves the character reference at `s[i]`, if there is one,
+// returning the new start of the pending text and our position in `s`.
+func (t *textStream) addRef(s string, source, n, i int) (int, int) {
+	ref := entityRef.FindString(s[i:])
+	if ref == "" {
+		return n, i
+	} else if value := html.UnescapeString(ref); value != ref {
+		t.add(s[n:i], source+n)
+		t.add(value, -1)
+		return i + len(ref), i + len(ref) - 1
+	}
+	return n, i
+}
+
+// locate finds the given text token, which should be the next text in the
+// stream (ignoring whitespace), and returns its segments.
+//
+// If it isn't, we skip ahead to the token's next occurrence in the stream.
+// Since the stream only holds text, this can't match inside markup.
+func (t *textStream) locate(token string) []nlp.Segment {
+	text := t.text.String()
+
+	rest := strings.TrimLeftFunc(text[t.cursor:], unicode.IsSpace)
+	at := len(text) - len(rest)
+	if !strings.HasPrefix(rest, token) {
+		pos := strings.Index(text[t.cursor:], token)
+		if pos < 0 {
+			return nil
+		}
+		at = t.cursor + pos
+	}
+
+	t.cursor = at + len(token)
+	return nlp.SliceSegments(t.segments, at, t.cursor)
+}
+
+// realign converts the stream's source offsets, which refer to `edited`,
+// into offsets in `original`.
+//
+// This is necessary because we parse a copy of the file in which ignored
+// content has been replaced (see `applyPatterns`). It returns false if we
+// couldn't align the two.
+func (t *textStream) realign(edited, original string) bool {
+	if edited == original {
+		return true
+	}
+
+	aligned, ok := alignContent(edited, original)
+	if !ok {
+		return false
+	}
+
+	segments := []nlp.Segment{}
+	for _, s := range t.segments {
+		for _, a := range aligned {
+			lo, hi := s.Source, s.Source+s.Size
+			if lo < a.Start {
+				lo = a.Start
+			}
+			if hi > a.Start+a.Size {
+				hi = a.Start + a.Size
+			}
+			if lo < hi {
+				segments = append(segments, nlp.Segment{
+					Start:  s.Start + (lo - s.Source),
+					Source: a.Source + (lo - a.Start),
+					Size:   hi - lo})
+			}
+		}
+	}
+	t.segments = segments
+
+	return true
+}
+
+// alignContent finds the parts of `edited` that are unchanged copies of
+// `original`, as segments whose `Start` is in `edited` and whose `Source` is
+// in `original`.
+//
+// It computes the shortest edit script between the two (Myers' algorithm),
+// and gives up if that's longer than `maxEdits`.
+func alignContent(edited, original string) ([]nlp.Segment, bool) {
+	n, m := len(edited), len(original)
+
+	offset := maxEdits + 1
+	v := make([]int, 2*offset+1)
+
+	trace := [][]int{}
+	for d := 0; d <= maxEdits; d++ {
+		trace = append(trace, append([]int{}, v...))
+		for k := -d; k <= d; k += 2 {
+			x := v[offset+k-1] + 1
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			}
+
+			y := x - k
+			for x < n && y < m && edited[x] == original[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(trace, offset, n, m), true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// backtrack recovers the unchanged runs from the trace of `alignContent`.
+func backtrack(trace [][]int, offset, x, y int) []nlp.Segment {
+	runs := []nlp.Segment{}
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+
+		k := x - y
+		prev := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prev = k + 1
+		}
+
+		prevX := v[offset+prev]
+		start := prevX
+		if prev == k-1 {
+			start++
+		}
+
+		if x > start {
+			runs = append(runs, nlp.Segment{Start: start, Source: start - k, Size: x - start})
+		}
+		x, y = prevX, prevX-prev
+	}
+
+	if x > 0 {
+		runs = append(runs, nlp.Segment{Start: 0, Source: 0, Size: x})
+	}
+
+	for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+		runs[i], runs[j] = runs[j], runs[i]
+	}
+	return runs
+}
@@ -0,0 +1,84 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark/text"
+)
+
+func TestMarkdownText(t *testing.T) {
+	original := strings.Join([]string{
+		"---",
+		"title: bar",
+		"---",
+		"",
+		"[bar](bar) bar \\*baz\\* &amp; baz",
+		"",
+		"> Some `bar` and <span title=\"bar\">bar</span> here.",
+		""}, "\n")
+
+	edited := reFrontMatter.ReplaceAllString(original, "\n```\n$1\n```\n")
+
+	src := []byte(edited)
+	stream := markdownText(goldMd.Parser().Parse(text.NewReader(src)), src)
+	if !stream.realign(edited, original) {
+		t.Fatal("unable to align the content")
+	}
+
+	tokens := []struct {
+		token string
+		at    string // the source text that starts with the token
+	}{
+		{"title: bar", "title: bar"},
+		{"bar", "bar](bar)"},
+		{"bar *baz* & baz", "bar \\*baz"},
+		{"Some", "Some"},
+		{"bar", "bar` and"},
+		{"and", "and <span"},
+		{"bar", "bar</span>"},
+		{"here.", "here."},
+	}
+	for _, tt := range tokens {
+		found := stream.locate(tt.token)
+		if len(found) == 0 {
+			t.Fatalf("%q: not found", tt.token)
+		} else if want := strings.Index(original, tt.at); found[0].Source != want {
+			t.Errorf("%q: expected %d, got %d", tt.token, want, found[0].Source)
+		}
+
+		for _, s := range found {
+			got := original[s.Source : s.Source+s.Size]
+			if want := tt.token[s.Start : s.Start+s.Size]; got != want {
+				t.Errorf("%q: %q mapped to %q", tt.token, want, got)
+			}
+		}
+	}
+}
+
+func TestAlignContent(t *testing.T) {
+	original := "A `b` c\nd e"
+	edited := "A ``b`` c\n```\nd\n```\n e"
+
+	aligned, ok := alignContent(edited, original)
+	if !ok {
+		t.Fatal("unable to align the content")
+	}
+
+	size := 0
+	for _, a := range aligned {
+		got := original[a.Source : a.Source+a.Size]
+		if want := edited[a.Start : a.Start+a.Size]; got != want {
+			t.Errorf("%q aligned with %q", want, got)
+		}
+		size += a.Size
+	}
+
+	if size != len(original) {
+		t.Errorf("expected %d aligned bytes, got %d", len(original), size)
+	}
+
+	if _, ok = alignContent(strings.Repeat("a", maxEdits+1), ""); ok {
+		t.Error("expected too many edits to fail")
+	}
+}
@@ -33,12 +33,57 @@ type walker struct {
 
 	begin int
 	end   int
+
+	// source is the file's original content, which we use to map each text
+	// token back to its location. Unlike `context`, it's never edited, so we
+	// instead track our progress through it with `cursor`.
+	source string
+	cursor int
+
+	// text holds the file's text, with source positions, if the format's
+	// parser provides them.
+	text *textStream
+
+	// found holds the source segments of the last text token, relative to
+	// the start of the token.
+	found []nlp.Segment
+
+	// segments maps the text we've collected for the current block back to
+	// the source; size is the length of that text.
+	segments []nlp.Segment
+	size     int
+
+	// headings holds the blocks of each heading we've seen, in order, along
+	// with the file's outline.
+	headings []nlp.Block
+	outline  []core.Heading
+
+	// sections holds the summarized content under each heading (see
+	// `lintSections`).
+	sections []*section
+}
+
+// A section is the summarized content under a heading (or before the first
+// one): its text is the heading, a blank line, and then its content.
+type section struct {
+	text     strings.Builder
+	segments []nlp.Segment
+	metrics  map[string]int
+}
+
+func (s *section) add(b nlp.Block, sep string) {
+	for _, seg := range b.Segments {
+		seg.Start += s.text.Len()
+		s.segments = append(s.segments, seg)
+	}
+	s.text.WriteString(b.Text + sep)
 }
 
 func newWalker(f *core.File, raw []byte, offset int) *walker {
 	return &walker{
 		lines:   len(f.Lines) + offset,
 		context: string2ByteSlice(f.Content),
+		source:  f.SourceMap().Content(),
 		z:       html.NewTokenizer(bytes.NewReader(raw))}
 }
 
@@ -68,6 +113,8 @@ func (w *walker) reset() {
 	}
 	w.queue = []string{}
 	w.tagHistory = []string{}
+	w.segments = nil
+	w.size = 0
 }
 
 func (w *walker) getCtx() string {
@@ -81,7 +128,76 @@ func (w *walker) append(text string) {
 			w.idx = pos
 		}
 		w.queue = append(w.queue, text)
+		w.locate(text)
+	}
+}
+
+// locate finds the given text token in the source.
+//
+// If the format's parser gave us source positions, we use those; otherwise,
+// we fall back to searching the source (see `search`).
+func (w *walker) locate(text string) {
+	if w.text == nil {
+		w.search(text)
+		return
+	}
+
+	w.found = w.text.locate(text)
+	if n := len(w.found); n > 0 {
+		if end := w.found[n-1].Source + w.found[n-1].Size; end > w.cursor {
+			w.cursor = end
+		}
+	}
+}
+
+// search finds the given text token in the source by searching forward
+// from the end of the last one.
+//
+// Tokens are located line-by-line since markup (e.g., a Markdown list or
+// blockquote) can add prefixes to each line that aren't part of the text.
+// Lines that we can't find verbatim (e.g., due to escaped characters) are
+// left unmapped.
+func (w *walker) search(text string) {
+	w.found = nil
+
+	start := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		s := strings.TrimSuffix(line, "\n")
+		if s != "" {
+			if pos := strings.Index(w.source[w.cursor:], s); pos >= 0 {
+				w.cursor += pos
+				w.found = append(w.found, nlp.Segment{
+					Start: start, Source: w.cursor, Size: len(s)})
+				w.cursor += len(s)
+			}
+		}
+		start += len(line)
+	}
+}
+
+// line returns the line on which `text` (e.g., a comment) next appears in
+// the source, or 0 if we can't find it.
+func (w *walker) line(text string) int {
+	pos := strings.Index(w.source[w.cursor:], text)
+	if pos < 0 {
+		return 0
 	}
+	w.cursor += pos
+	return strings.Count(w.source[:w.cursor], "\n") + 1
+}
+
+// record notes that `written` -- the last text token, as written to the
+// current block -- begins at `at` in the block's text.
+func (w *walker) record(at int, token, written string) {
+	if strings.HasSuffix(written, token) {
+		// We don't map any padding, but the token itself is unchanged.
+		shift := at + len(written) - len(token)
+		for _, s := range w.found {
+			s.Start += shift
+			w.segments = append(w.segments, s)
+		}
+	}
+	w.size = at + len(written)
 }
 
 func (w *walker) addTag(tag string) {
@@ -128,6 +244,53 @@ func (w *walker) block(text, scope string) nlp.Block {
 	return nlp.NewLinedBlock(w.getCtx(), text, scope, line, nil)
 }
 
+// textBlock is like `block`, but for text that we've collected from the
+// current block (which may have been trimmed from the left).
+func (w *walker) textBlock(text, scope string) nlp.Block {
+	b := w.block(text, scope)
+	if shift := w.size - len(text); shift >= 0 {
+		b.Segments = nlp.SliceSegments(w.segments, shift, w.size)
+	}
+	return b
+}
+
+// addHeading records a heading (of the given level) in the file's outline
+// and starts a new section.
+func (w *walker) addHeading(b nlp.Block, level int) {
+	w.headings = append(w.headings, b)
+	w.outline = append(w.outline, core.Heading{Level: level, Text: b.Text})
+
+	s := &section{metrics: map[string]int{}}
+	// NOTE: A heading's text may span multiple lines (e.g., a Setext
+	// heading), so we replace its newlines to keep it in one paragraph.
+	b.Text = strings.ReplaceAll(b.Text, "\n", " ")
+	s.add(b, "\n\n")
+	w.sections = append(w.sections, s)
+}
+
+// section returns the current section.
+func (w *walker) section() *section {
+	if len(w.sections) == 0 {
+		// The content before the first heading.
+		s := &section{metrics: map[string]int{}}
+		s.text.WriteString("\n\n")
+		w.sections = append(w.sections, s)
+	}
+	return w.sections[len(w.sections)-1]
+}
+
+// summarize adds `b` to the current section's summarized content.
+func (w *walker) summarize(b nlp.Block) {
+	w.section().add(b, "\n\n")
+}
+
+// count records an occurrence of the markup scope `scope` (e.g.,
+// `heading.h2`) in the file and in the current section.
+func (w *walker) count(f *core.File, scope string) {
+	f.Metrics[scope]++
+	w.section().metrics[scope]++
+}
+
 func (w *walker) walk() (html.TokenType, html.Token, string) {
 	tokt := w.z.Next()
 	tok := w.z.Token()
@@ -43,5 +43,5 @@ func (l Linter) lintXML(file *core.File) error {
 		return core.NewE100(file.Path, errors.New(eut.String()))
 	}
 
-	return l.lintHTMLTokens(file, out.Bytes(), 0)
+	return l.lintHTMLTokens(file, out.Bytes(), 0, nil)
 }
@@ -0,0 +1,187 @@
+package nlp
+
+import (
+	"strings"
+
+	"github.com/jdkato/prose/tag"
+)
+
+// The grammatical relations assigned by `Parse`, which follow the (older)
+// Universal Dependencies naming.
+const (
+	Subject        = "nsubj"     // "The team" wrote the report.
+	PassiveSubject = "nsubjpass" // "The report" was written.
+	Object         = "obj"       // The team wrote "the report".
+	Auxiliary      = "aux"       // The team "has" written it.
+	PassiveAux     = "auxpass"   // The report "was" written.
+	Agent          = "agent"     // The report was written by "the team".
+	Negation       = "neg"       // The report was "not" written.
+)
+
+// A Dependency is a word's relation to its head (another word in the same
+// sentence).
+//
+// Words without a head (e.g., the main verb of a clause) have a `Head` of
+// -1 and an empty `Rel`.
+type Dependency struct {
+	Head int
+	Rel  string
+}
+
+// beForms lists the forms of "be", which (along with those of "get") form
+// the passive voice.
+var beForms = []string{
+	"be", "am", "is", "are", "was", "were", "been", "being", "'s", "'re", "'m",
+}
+
+var passiveAux = append([]string{"get", "gets", "got", "gotten", "getting"}, beForms...)
+
+// clauseBounds are the tags that end a clause when looking for a verb's
+// subject or object.
+var clauseBounds = []string{",", ":", "(", ")", "CC", "WDT", "WP", "WRB", "."}
+
+// nominalTags are the tags that make up a (simple) noun phrase.
+var nominalTags = []string{"DT", "PDT", "PRP$", "POS", "CD", "JJ", "JJR", "JJS"}
+
+// Parse assigns a head and relation to each of the (tagged) words of a
+// sentence.
+//
+// It's a shallow, rule-based parser: it finds each verb group (e.g., "could
+// have been promoted"), attaches its auxiliaries and negation to the main
+// verb, and then looks for a subject before the group and an object (or, for
+// the passive voice, an agent) after it. It doesn't try to attach anything
+// else.
+func Parse(words []tag.Token) []Dependency {
+	deps := make([]Dependency, len(words))
+	for i := range deps {
+		deps[i].Head = -1
+	}
+
+	bound := 0
+	for i := 0; i < len(words); i++ {
+		if !isVerb(words[i].Tag) {
+			if isBound(words[i]) {
+				bound = i + 1
+			}
+			continue
+		}
+
+		// The verb group runs through any adverbs (e.g., "was not
+		// reviewed") to its last verb, which is the main verb.
+		main := i
+		for j := i + 1; j < len(words); j++ {
+			if isVerb(words[j].Tag) {
+				main = j
+			} else if !strings.HasPrefix(words[j].Tag, "RB") {
+				break
+			}
+		}
+
+		passive := false
+		if main > i && (words[main].Tag == "VBN" || words[main].Tag == "VBD") {
+			for j := i; j < main; j++ {
+				passive = passive || isPassiveAux(words[j])
+			}
+		}
+
+		for j := i; j < main; j++ {
+			switch {
+			case isVerb(words[j].Tag) && passive && isPassiveAux(words[j]):
+				deps[j] = Dependency{Head: main, Rel: PassiveAux}
+			case isVerb(words[j].Tag):
+				deps[j] = Dependency{Head: main, Rel: Auxiliary}
+			case isNegation(words[j].Text):
+				deps[j] = Dependency{Head: main, Rel: Negation}
+			}
+		}
+
+		if subj := subjectOf(words[bound:i]); subj >= 0 {
+			rel := Subject
+			if passive {
+				rel = PassiveSubject
+			}
+			deps[bound+subj] = Dependency{Head: main, Rel: rel}
+		}
+
+		if passive {
+			if agent := agentOf(words, main+1); agent >= 0 {
+				deps[agent] = Dependency{Head: main, Rel: Agent}
+			}
+		} else if !stringInSlice(strings.ToLower(words[main].Text), beForms) {
+			// NOTE: We skip "be" since what follows it isn't an object.
+			if obj := phraseHead(words, main+1); obj >= 0 {
+				deps[obj] = Dependency{Head: main, Rel: Object}
+			}
+		}
+
+		i = main
+		bound = main + 1
+	}
+
+	return deps
+}
+
+// subjectOf returns the index of the head of the first noun phrase in
+// `words` that isn't the object of a preposition, or -1.
+func subjectOf(words []tag.Token) int {
+	for i := 0; i < len(words); i++ {
+		if i > 0 && (words[i-1].Tag == "IN" || words[i-1].Tag == "TO") {
+			continue
+		} else if head := phraseHead(words, i); head >= 0 {
+			return head
+		}
+	}
+	return -1
+}
+
+// agentOf returns the index of the head of the noun phrase following "by" in
+// the clause starting at `start`, or -1.
+func agentOf(words []tag.Token, start int) int {
+	for i := start; i < len(words) && !isBound(words[i]) && !isVerb(words[i].Tag); i++ {
+		if words[i].Tag == "IN" && strings.EqualFold(words[i].Text, "by") {
+			return phraseHead(words, i+1)
+		}
+	}
+	return -1
+}
+
+// phraseHead returns the index of the head (last noun or pronoun) of the
+// noun phrase starting at `start` (after any adverbs or particles), or -1.
+func phraseHead(words []tag.Token, start int) int {
+	i := start
+	for i < len(words) && (strings.HasPrefix(words[i].Tag, "RB") || words[i].Tag == "RP") {
+		i++
+	}
+
+	head := -1
+	for ; i < len(words); i++ {
+		t := words[i].Tag
+		if isNominal(t) {
+			head = i
+		} else if !stringInSlice(t, nominalTags) {
+			break
+		}
+	}
+
+	return head
+}
+
+func isVerb(t string) bool {
+	return strings.HasPrefix(t, "VB") || t == "MD"
+}
+
+func isNominal(t string) bool {
+	return strings.HasPrefix(t, "NN") || stringInSlice(t, []string{"PRP", "EX", "CD"})
+}
+
+func isBound(word tag.Token) bool {
+	return stringInSlice(word.Tag, clauseBounds) || word.Text == ";"
+}
+
+func isPassiveAux(word tag.Token) bool {
+	return isVerb(word.Tag) && stringInSlice(strings.ToLower(word.Text), passiveAux)
+}
+
+func isNegation(s string) bool {
+	return stringInSlice(strings.ToLower(s), []string{"not", "n't", "never"})
+}
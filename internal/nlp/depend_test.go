@@ -0,0 +1,43 @@
+package nlp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string][]string{
+		"The report was written by the team.": {
+			"report:nsubjpass:written", "was:auxpass:written", "team:agent:written"},
+		"The results of the test were quickly ignored.": {
+			"results:nsubjpass:ignored", "were:auxpass:ignored"},
+		"She could have been promoted.": {
+			"She:nsubjpass:promoted", "could:aux:promoted", "have:aux:promoted",
+			"been:auxpass:promoted"},
+		"The team wrote the report and it was not reviewed.": {
+			"team:nsubj:wrote", "report:obj:wrote", "it:nsubjpass:reviewed",
+			"was:auxpass:reviewed", "not:neg:reviewed"},
+		"In 2020, the new server was installed by our engineers.": {
+			"server:nsubjpass:installed", "was:auxpass:installed",
+			"engineers:agent:installed"},
+		"He has broken the window.": {
+			"He:nsubj:broken", "has:aux:broken", "window:obj:broken"},
+		"The answer is yes.": {
+			"answer:nsubj:is"},
+	}
+
+	for text, expected := range cases {
+		words, deps := TextToDependencies(text, nil)
+
+		found := []string{}
+		for i, dep := range deps {
+			if dep.Rel != "" {
+				found = append(found, words[i].Text+":"+dep.Rel+":"+words[dep.Head].Text)
+			}
+		}
+
+		if !reflect.DeepEqual(found, expected) {
+			t.Errorf("%q: expected %v, got %v", text, expected, found)
+		}
+	}
+}
@@ -15,6 +15,7 @@ type SegmentResult struct {
 
 type TagResult struct {
 	Tokens []tag.Token
+	Deps   []Dependency // optional; see `TextToDependencies`
 }
 
 func post(url string) ([]byte, error) {
@@ -0,0 +1,214 @@
+package nlp
+
+import (
+	"strings"
+	"unicode"
+)
+
+// The kinds of inflection returned by `Inflections`.
+const (
+	Plural     = "plural"     // utilizes (also the third-person singular)
+	Past       = "past"       // utilized
+	Participle = "participle" // utilized
+	Gerund     = "gerund"     // utilizing
+)
+
+// An Inflection is an inflected form of a word.
+type Inflection struct {
+	Kind string
+	Form string
+}
+
+// irregularVerbs maps common irregular verbs to their past tense and past
+// participle.
+var irregularVerbs = map[string][2]string{
+	"be":         {"was", "been"},
+	"become":     {"became", "become"},
+	"begin":      {"began", "begun"},
+	"break":      {"broke", "broken"},
+	"bring":      {"brought", "brought"},
+	"build":      {"built", "built"},
+	"buy":        {"bought", "bought"},
+	"choose":     {"chose", "chosen"},
+	"come":       {"came", "come"},
+	"do":         {"did", "done"},
+	"draw":       {"drew", "drawn"},
+	"drive":      {"drove", "driven"},
+	"feel":       {"felt", "felt"},
+	"find":       {"found", "found"},
+	"forget":     {"forgot", "forgotten"},
+	"get":        {"got", "gotten"},
+	"give":       {"gave", "given"},
+	"go":         {"went", "gone"},
+	"have":       {"had", "had"},
+	"hide":       {"hid", "hidden"},
+	"hold":       {"held", "held"},
+	"keep":       {"kept", "kept"},
+	"know":       {"knew", "known"},
+	"lead":       {"led", "led"},
+	"leave":      {"left", "left"},
+	"let":        {"let", "let"},
+	"lose":       {"lost", "lost"},
+	"make":       {"made", "made"},
+	"mean":       {"meant", "meant"},
+	"meet":       {"met", "met"},
+	"pay":        {"paid", "paid"},
+	"put":        {"put", "put"},
+	"read":       {"read", "read"},
+	"run":        {"ran", "run"},
+	"say":        {"said", "said"},
+	"see":        {"saw", "seen"},
+	"sell":       {"sold", "sold"},
+	"send":       {"sent", "sent"},
+	"set":        {"set", "set"},
+	"show":       {"showed", "shown"},
+	"speak":      {"spoke", "spoken"},
+	"spend":      {"spent", "spent"},
+	"stand":      {"stood", "stood"},
+	"take":       {"took", "taken"},
+	"tell":       {"told", "told"},
+	"think":      {"thought", "thought"},
+	"understand": {"understood", "understood"},
+	"win":        {"won", "won"},
+	"write":      {"wrote", "written"},
+}
+
+// doubled lists multi-syllable words that double their final consonant
+// (e.g., "commit" -> "committed").
+var doubled = []string{
+	"admit", "commit", "compel", "control", "equip", "occur", "omit", "patrol",
+	"permit", "prefer", "refer", "submit", "transfer", "upset",
+}
+
+// Inflections returns the regular (and some irregular) English inflections
+// of `word`, which is treated as both a noun and a verb.
+//
+// The forms are given in a fixed order (plural, past, participle, gerund)
+// and only include those that differ from `word` and from each other.
+func Inflections(word string) []Inflection {
+	inflections := []Inflection{}
+
+	seen := map[string]bool{word: true}
+	for _, kind := range []string{Plural, Past, Participle, Gerund} {
+		form := Inflect(word, kind)
+		if !seen[form] {
+			inflections = append(inflections, Inflection{Kind: kind, Form: form})
+			seen[form] = true
+		}
+	}
+
+	return inflections
+}
+
+// Inflect returns the form of `word` given by `kind`.
+//
+// Only single (ASCII) words are inflected; anything else is returned as is.
+func Inflect(word, kind string) string {
+	if word == "" || strings.IndexFunc(word, isNotLetter) >= 0 {
+		return word
+	} else if len(word) > 1 && strings.ToUpper(word) == word {
+		// For example, "UTILIZE" -> "UTILIZED".
+		return strings.ToUpper(inflect(strings.ToLower(word), kind))
+	}
+	return inflect(word, kind)
+}
+
+func inflect(word, kind string) string {
+	lower := strings.ToLower(word)
+	if forms, ok := irregularVerbs[lower]; ok && (kind == Past || kind == Participle) {
+		form := forms[0]
+		if kind == Participle {
+			form = forms[1]
+		}
+		return matchCase(word, form)
+	}
+
+	switch kind {
+	case Plural:
+		switch {
+		case lower == "be":
+			return matchCase(word, "is")
+		case lower == "have":
+			return matchCase(word, "has")
+		case lower == "do" || lower == "go":
+			return word + "es"
+		case hasAnySuffix(lower, []string{"s", "x", "z", "ch", "sh"}):
+			return word + "es"
+		case endsWithConsonantY(lower):
+			return word[:len(word)-1] + "ies"
+		}
+		return word + "s"
+	case Past, Participle:
+		switch {
+		case strings.HasSuffix(lower, "e"):
+			return word + "d"
+		case endsWithConsonantY(lower):
+			return word[:len(word)-1] + "ied"
+		case shouldDouble(lower):
+			return word + word[len(word)-1:] + "ed"
+		}
+		return word + "ed"
+	case Gerund:
+		switch {
+		case strings.HasSuffix(lower, "ie"):
+			return word[:len(word)-2] + "ying"
+		case lower == "be" || hasAnySuffix(lower, []string{"ee", "oe", "ye"}):
+			return word + "ing"
+		case strings.HasSuffix(lower, "e") && len(lower) > 1:
+			return word[:len(word)-1] + "ing"
+		case shouldDouble(lower):
+			return word + word[len(word)-1:] + "ing"
+		}
+		return word + "ing"
+	}
+
+	return word
+}
+
+func isNotLetter(r rune) bool {
+	return r > unicode.MaxASCII || !unicode.IsLetter(r)
+}
+
+func isVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
+
+func endsWithConsonantY(s string) bool {
+	n := len(s)
+	return n > 1 && s[n-1] == 'y' && !isVowel(s[n-2])
+}
+
+// shouldDouble reports whether `s` doubles its final consonant before a
+// suffix: one-syllable, consonant-vowel-consonant words (e.g., "stop") and
+// the exceptions in `doubled`.
+func shouldDouble(s string) bool {
+	for _, w := range doubled {
+		if s == w {
+			return true
+		}
+	}
+
+	n := len(s)
+	if n < 3 || strings.IndexByte("wxy", s[n-1]) >= 0 {
+		return false
+	} else if isVowel(s[n-1]) || !isVowel(s[n-2]) || isVowel(s[n-3]) {
+		return false
+	}
+
+	syllables := 0
+	for i := 0; i < n; i++ {
+		if isVowel(s[i]) && (i == 0 || !isVowel(s[i-1])) {
+			syllables++
+		}
+	}
+
+	return syllables == 1
+}
+
+// matchCase capitalizes `form` if `word` is capitalized.
+func matchCase(word, form string) string {
+	if unicode.IsUpper(rune(word[0])) {
+		return strings.ToUpper(form[:1]) + form[1:]
+	}
+	return form
+}
@@ -0,0 +1,57 @@
+package nlp
+
+import (
+	"testing"
+)
+
+func TestInflect(t *testing.T) {
+	cases := []struct {
+		word, kind, expected string
+	}{
+		{"utilize", Plural, "utilizes"},
+		{"utilize", Past, "utilized"},
+		{"utilize", Gerund, "utilizing"},
+		{"use", Past, "used"},
+		{"Use", Gerund, "Using"},
+		{"UTILIZE", Past, "UTILIZED"},
+		{"apply", Plural, "applies"},
+		{"apply", Past, "applied"},
+		{"apply", Gerund, "applying"},
+		{"push", Plural, "pushes"},
+		{"stop", Past, "stopped"},
+		{"stop", Gerund, "stopping"},
+		{"commit", Past, "committed"},
+		{"open", Past, "opened"},
+		{"die", Gerund, "dying"},
+		{"see", Gerund, "seeing"},
+		{"write", Past, "wrote"},
+		{"write", Participle, "written"},
+		{"Write", Participle, "Written"},
+		{"go", Plural, "goes"},
+		{"log in", Past, "log in"},
+		{"café", Past, "café"},
+	}
+
+	for _, c := range cases {
+		if observed := Inflect(c.word, c.kind); observed != c.expected {
+			t.Errorf("%s (%s): expected '%s', got '%s'", c.word, c.kind, c.expected, observed)
+		}
+	}
+}
+
+func TestInflections(t *testing.T) {
+	forms := []string{}
+	for _, inf := range Inflections("utilize") {
+		forms = append(forms, inf.Form)
+	}
+
+	expected := []string{"utilizes", "utilized", "utilizing"}
+	if len(forms) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, forms)
+	}
+	for i := range expected {
+		if forms[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, forms)
+		}
+	}
+}
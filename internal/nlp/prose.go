@@ -53,14 +53,31 @@ func textToWords(text string, nlp bool) []string {
 
 // TextToTokens converts a string to a slice of tokens.
 func TextToTokens(text string, nlp *Info) []tag.Token {
+	return tagText(text, nlp).Tokens
+}
+
+// TextToDependencies converts a string to a slice of tokens along with the
+// dependency (see `Parse`) of each.
+//
+// If the NLP endpoint provides its own dependencies (one per token), we use
+// those instead.
+func TextToDependencies(text string, nlp *Info) ([]tag.Token, []Dependency) {
+	result := tagText(text, nlp)
+	if len(result.Deps) != len(result.Tokens) {
+		result.Deps = Parse(result.Tokens)
+	}
+	return result.Tokens, result.Deps
+}
+
+func tagText(text string, nlp *Info) TagResult {
 	// Determine if (and how) we need to do POS tagging.
 	if nlp == nil || nlp.Endpoint == "" {
 		// Fall back to our internal library (English-only).
-		return doTag(textToWords(text, true))
+		return TagResult{Tokens: doTag(textToWords(text, true))}
 	}
 	result, err := pos(text, nlp.Lang, nlp.Endpoint)
 	if err != nil {
 		panic(err)
 	}
-	return result.Tokens
+	return result
 }
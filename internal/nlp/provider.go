@@ -13,6 +13,8 @@ type Block struct {
 	Scope   string // section selector
 	Parent  string // parent (fully-qualfied) selector
 	Text    string // text content
+
+	Segments []Segment // the source locations of `Text` (if known)
 }
 
 // NewBlock makes a new Block with prepared text and a Selector.
@@ -91,24 +93,37 @@ func (n *Info) doNLP(blk *Block, seg segmenter) ([]Block, error) {
 	ext := n.Scope
 
 	if n.Splitting {
+		start := 0
 		for _, p := range strings.SplitAfter(blk.Text, "\n\n") {
-			blks = append(
-				blks, NewLinedBlock(ctx, p, "paragraph"+ext, idx, nil))
+			b := NewLinedBlock(ctx, p, "paragraph"+ext, idx, nil)
+			b.Segments = blk.sub(start, p)
+			blks = append(blks, b)
+			start += len(p)
 		}
 	}
 
 	if n.Segmentation {
+		start := 0
 		for _, s := range seg(blk.Text) {
 			s = strings.TrimSpace(s)
 			if s != "" {
-				blks = append(
-					blks, NewLinedBlock(ctx, s, "sentence"+ext, idx, nil))
+				b := NewLinedBlock(ctx, s, "sentence"+ext, idx, nil)
+				// The segmenter doesn't report offsets, so we find each
+				// sentence ourselves (in order).
+				if pos := strings.Index(blk.Text[start:], s); pos >= 0 {
+					start += pos
+					b.Segments = blk.sub(start, s)
+					start += len(s)
+				}
+				blks = append(blks, b)
 			}
 		}
 	}
 
-	blks = append(
-		blks, NewLinedBlock(ctx, blk.Text, "text"+ext, idx, nil))
+	text := NewLinedBlock(ctx, blk.Text, "text"+ext, idx, nil)
+	text.Segments = blk.Segments
+
+	blks = append(blks, text)
 
 	return blks, nil
 }
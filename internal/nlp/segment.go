@@ -0,0 +1,57 @@
+package nlp
+
+// A Segment maps a run of a Block's text back to its location in the
+// original source.
+//
+// Markup converters (such as the HTML walker) only record segments for text
+// that's copied verbatim from the source -- anything they add or rewrite
+// (e.g., padding between inline tags) is left unmapped.
+type Segment struct {
+	Start  int // byte offset into the Block's text
+	Source int // byte offset into the source
+	Size   int // length in bytes
+}
+
+// Source returns the source offset of the byte at `i` in the Block's text
+// and the number of mapped bytes that follow it (including itself).
+//
+// It returns (-1, 0) if the byte isn't mapped.
+func (b Block) Source(i int) (int, int) {
+	for _, s := range b.Segments {
+		if i >= s.Start && i < s.Start+s.Size {
+			return s.Source + (i - s.Start), s.Start + s.Size - i
+		}
+	}
+	return -1, 0
+}
+
+// sub returns the segments for `txt`, which starts at `start` in the block's
+// text.
+func (b *Block) sub(start int, txt string) []Segment {
+	if len(b.Segments) == 0 {
+		return nil
+	}
+	return SliceSegments(b.Segments, start, start+len(txt))
+}
+
+// SliceSegments returns the segments for the text between `start` and `end`,
+// relative to `start`.
+func SliceSegments(segs []Segment, start, end int) []Segment {
+	sliced := []Segment{}
+	for _, s := range segs {
+		lo, hi := s.Start, s.Start+s.Size
+		if lo < start {
+			lo = start
+		}
+		if hi > end {
+			hi = end
+		}
+		if lo < hi {
+			sliced = append(sliced, Segment{
+				Start:  lo - start,
+				Source: s.Source + (lo - s.Start),
+				Size:   hi - lo})
+		}
+	}
+	return sliced
+}
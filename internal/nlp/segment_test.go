@@ -0,0 +1,24 @@
+package nlp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSliceSegments(t *testing.T) {
+	// "A `TODO` here" -> "A **** here", with the code span unmapped.
+	segs := []Segment{{Start: 0, Source: 10, Size: 2}, {Start: 6, Source: 18, Size: 5}}
+
+	got := SliceSegments(segs, 1, 9)
+	want := []Segment{{Start: 0, Source: 11, Size: 1}, {Start: 5, Source: 18, Size: 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	blk := Block{Text: "A **** here", Segments: segs}
+	for i, expected := range []int{10, 11, -1, -1, -1, -1, 18, 19} {
+		if src, _ := blk.Source(i); src != expected {
+			t.Errorf("Source(%d): expected %d, got %d", i, expected, src)
+		}
+	}
+}
@@ -44,3 +44,12 @@ func allNonLetter(s string) bool {
 	}
 	return true
 }
+
+func stringInSlice(s string, slice []string) bool {
+	for _, v := range slice {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+//go:build ignore
+
+// This program writes the schemas returned by `schema.Generate` to the
+// current directory.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/errata-ai/vale/v2/schema"
+)
+
+func main() {
+	files, err := schema.Generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for name, content := range files {
+		if err = os.WriteFile(name, content, 0644); err != nil { //nolint:gosec
+			log.Fatal(err)
+		}
+	}
+}
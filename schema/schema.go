@@ -0,0 +1,38 @@
+// Package schema holds the JSON Schemas for `.vale.ini` (`config.json`) and
+// rule definitions (`rule.json`).
+//
+// The schemas are generated from the definitions in `internal/core` and
+// `internal/check`, so run `go generate ./schema` after changing either.
+package schema
+
+//go:generate go run gen.go
+
+import (
+	"encoding/json"
+
+	"github.com/errata-ai/vale/v2/internal/check"
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+// Generate returns the contents of each schema file, using the default
+// alert levels (`vale schema` lists those of the current configuration).
+func Generate() (map[string][]byte, error) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	for name, schema := range map[string]interface{}{
+		"config.json": core.ConfigSchema(cfg.AlertLevels),
+		"rule.json":   check.RuleSchema(cfg.AlertLevels),
+	} {
+		b, err := json.MarshalIndent(schema, "", "    ")
+		if err != nil {
+			return nil, err
+		}
+		files[name] = append(b, '\n')
+	}
+
+	return files, nil
+}
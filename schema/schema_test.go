@@ -0,0 +1,23 @@
+package schema
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestSchemasUpToDate(t *testing.T) {
+	files, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, expected := range files {
+		committed, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(committed, expected) {
+			t.Errorf("%s is out of date; run `go generate ./schema`", name)
+		}
+	}
+}
@@ -21,6 +21,14 @@ Feature: Checks
             test.md:9:5:Checks.MultiCapture:'NFL' has no definition
             """
 
+    Scenario: Plugin
+        When I test "checks/Plugin"
+        Then the output should contain exactly:
+            """
+            test.md:3:1:Checks.Plugin:Resolve 'TODO' before publishing.
+            test.md:7:1:Checks.Plugin:Resolve 'TODO' before publishing.
+            """
+
     Scenario: Occurrence
         When I test "checks/Occurrence"
         Then the output should contain exactly:
@@ -106,3 +114,89 @@ Feature: Checks
             test.txt:25:1:LanguageTool.Metadata:Use data and metadata as plural nouns.
             test.txt:29:1:LanguageTool.Metadata:Use data and metadata as plural nouns.
             """
+
+    Scenario: Per-section readability and metrics
+        Given a file named "_vale" with:
+            """
+            StylesPath = styles
+
+            [*]
+            BasedOnStyles = Docs
+            """
+        And a file named "styles/Docs/Grade.yml" with:
+            """
+            extends: readability
+            message: "Grade %s is too high (lines %s)."
+            level: error
+            metrics:
+              - Flesch-Kincaid
+            grade: 8
+            scope: section
+            """
+        And a file named "styles/Docs/Lists.yml" with:
+            """
+            extends: metric
+            message: "This section has %s list items (lines %s)."
+            level: warning
+            formula: list
+            condition: "> 1"
+            scope: section
+            """
+        And a file named "test.md" with:
+            """
+            Intro text is simple. It is fine.
+
+            ## Dense part
+
+            The institutionalization of interdisciplinary methodologies necessitates comprehensive reconsideration of epistemological presuppositions.
+
+            ## Easy part
+
+            We go. We see. We win.
+
+            - One.
+            - Two.
+
+            """
+        When I run vale "test.md"
+        Then the output should contain exactly:
+            """
+            test.md:3:4:Docs.Grade:Grade 41.26 is too high (lines 3-5).
+            test.md:7:4:Docs.Lists:This section has 2.00 list items (lines 7-12).
+            """
+        And the exit status should be 1
+
+    Scenario: Per-paragraph readability in plain text
+        Given a file named "_vale" with:
+            """
+            StylesPath = styles
+
+            [*]
+            BasedOnStyles = Docs
+            """
+        And a file named "styles/Docs/Grade.yml" with:
+            """
+            extends: readability
+            message: "Grade %s is too high (lines %s)."
+            level: error
+            metrics:
+              - Flesch-Kincaid
+            grade: 8
+            scope: paragraph
+            """
+        And a file named "test.txt" with:
+            """
+            Intro text is simple. It is fine.
+
+            We go. We see.
+
+            The institutionalization of interdisciplinary methodologies necessitates comprehensive reconsideration of epistemological presuppositions.
+
+            We win.
+            """
+        When I run vale "test.txt"
+        Then the output should contain exactly:
+            """
+            test.txt:5:1:Docs.Grade:Grade 41.26 is too high (lines 5-5).
+            """
+        And the exit status should be 1
@@ -25,3 +25,63 @@ Feature: Comments
     """
     test.org:17:21:vale.Redundancy:'ACT test' is redundant
     """
+
+  Scenario: Line-based suppressions
+    Given a file named "_vale" with:
+    """
+    StylesPath = ../../styles/
+    MinAlertLevel = suggestion
+
+    [*]
+    vale.Annotations = YES
+    """
+    And a file named "test.md" with:
+    """
+    <!-- vale-ignore-next-line vale.Annotations -- tracked elsewhere -->
+    A TODO here.
+    A TODO there.
+
+    <!-- vale-ignore-start -->
+    XXX one
+
+    FIXME two
+    <!-- vale-ignore-end -->
+
+    <!-- vale vale.* = NO -->
+    A NOTE here.
+    <!-- vale vale.* = YES -->
+
+    <!-- vale-ignore-next-line Foo.Bar -->
+    Nothing to see.
+    """
+    When I run vale "--report-unused-suppressions test.md"
+    Then the output should contain exactly:
+    """
+    test.md:3:3:vale.Annotations:'TODO' left in text
+    test.md:15:1:Vale.UnusedSuppression:'vale-ignore-next-line Foo.Bar' doesn't suppress any alerts.
+    """
+    And the exit status should be 0
+
+  Scenario: Levels of unused suppressions
+    Given a file named "_vale" with:
+    """
+    StylesPath = ../../styles/
+    MinAlertLevel = suggestion
+
+    [*]
+    vale.Annotations = YES
+    Vale.UnusedSuppression = error
+    """
+    And a file named "test.md" with:
+    """
+    A TODO here.
+
+    <!-- vale-ignore-next-line Foo.Bar -->
+    Nothing to see.
+    """
+    When I run vale "--report-unused-suppressions --minAlertLevel=error test.md"
+    Then the output should contain exactly:
+    """
+    test.md:3:1:Vale.UnusedSuppression:'vale-ignore-next-line Foo.Bar' doesn't suppress any alerts.
+    """
+    And the exit status should be 1
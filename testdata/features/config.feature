@@ -400,6 +400,7 @@ Feature: Config
             """
             test.md:1:6:write-good.E-Prime:Avoid using "is"
             test.md:1:11:write-good.Weasel:'very' is a weasel word!
+            test.md:1:42:write-good.E-Prime:Avoid using "is"
             """
         And the exit status should be 0
 
@@ -434,6 +435,109 @@ Feature: Config
             """
         And the exit status should be 0
 
+    Scenario: Fail on warnings
+        Given a file named "_vale" with:
+            """
+            StylesPath = ../../styles/
+            MinAlertLevel = warning
+
+            [*]
+            BasedOnStyles = write-good
+            """
+        When I run vale "--fail-on=warning test.md"
+        Then the output should contain exactly:
+            """
+            test.md:1:11:write-good.Weasel:'very' is a weasel word!
+            """
+        And the exit status should be 1
+
+    Scenario: Syntax-specific MinAlertLevel
+        Given a file named "_vale" with:
+            """
+            StylesPath = ../../styles/
+            MinAlertLevel = suggestion
+
+            [*]
+            BasedOnStyles = write-good
+
+            [*.md]
+            MinAlertLevel = error
+            write-good.Weasel = error
+            """
+        When I run vale "test.md test.py"
+        Then the output should contain exactly:
+            """
+            test.md:1:11:write-good.Weasel:'very' is a weasel word!
+            test.py:1:1:write-good.ThereIs:Don't start a sentence with '# There is'
+            test.py:1:9:write-good.E-Prime:Avoid using "is"
+            test.py:1:37:write-good.Weasel:'Very' is a weasel word!
+            test.py:1:60:write-good.E-Prime:Avoid using "is"
+            test.py:2:20:write-good.E-Prime:Avoid using "is"
+            test.py:2:39:write-good.E-Prime:Avoid using "is"
+            """
+        And the exit status should be 1
+
+    Scenario: StrictRules = YES
+        Given a file named "_vale" with:
+            """
+            StylesPath = styles
+            StrictRules = YES
+
+            [*]
+            BasedOnStyles = Strict
+            """
+        And a file named "styles/Strict/Typo.yml" with:
+            """
+            extends: consistency
+            message: "Inconsistent spelling of '%s'."
+            ignorecse: true
+            either:
+              advisor: adviser
+            """
+        When I run vale "test.md"
+        Then the output should contain:
+            """
+            has invalid keys: 'ignorecse'
+            """
+        And the exit status should be 2
+
+    Scenario: Rule tags
+        Given a file named "_vale" with:
+            """
+            StylesPath = styles
+            MinAlertLevel = suggestion
+
+            [tags]
+            legal = error
+
+            [*]
+            BasedOnStyles = Tagged
+            """
+        And a file named "styles/Tagged/Important.yml" with:
+            """
+            extends: existence
+            message: "Avoid '%s'."
+            level: suggestion
+            tags: [legal]
+            tokens:
+              - important
+            """
+        And a file named "styles/Tagged/Sentence.yml" with:
+            """
+            extends: existence
+            message: "Avoid '%s'."
+            level: suggestion
+            tags: [terminology]
+            tokens:
+              - sentence
+            """
+        When I run vale "--output=line --tags=legal test.md"
+        Then the output should contain exactly:
+            """
+            test.md:1:16:Tagged.Important:Avoid 'important'.
+            """
+        And the exit status should be 1
+
 #    NOTE: This idea was used in the now-deprecated Vale Server application.
 #
 #    Scenario: Local overrides
@@ -164,7 +164,7 @@ Feature: Lint
             test.jsx:1:4:vale.Annotations:'XXX' left in text
             test.jsx:4:6:vale.Annotations:'NOTE' left in text
             test.jsx:14:3:vale.Annotations:'XXX' left in text
-            test.jsx:18:39:vale.Annotations:'TODO' left in text
+            test.jsx:18:37:vale.Annotations:'TODO' left in text
             """
         And the exit status should be 0
 
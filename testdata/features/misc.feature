@@ -71,7 +71,7 @@ Feature: Misc
         When I test "misc/markup"
         Then the output should contain exactly:
             """
-            test.md:4:1:Markup.Repetition:"in" is repeated.
+            test.md:4:428:Markup.Repetition:"in" is repeated.
             test.md:50:11:Markup.SentSpacing:"d.A" must contain one and only one space.
             """
 
@@ -105,3 +105,136 @@ Feature: Misc
             test.md:23:78:Vale.Spelling:Did you really mean 'config'?
             test.md:23:85:Vale.Spelling:Did you really mean 'json'?
             """
+
+    Scenario: Repeated phrases and inline markup
+        Given a file named "_vale" with:
+            """
+            StylesPath = ../../styles/
+            MinAlertLevel = suggestion
+
+            [*]
+            vale.Annotations = YES
+            """
+        And a file named "test.md" with:
+            """
+            # Notes
+
+            A `TODO` here and a TODO there, then TODO again.
+
+            - item with **TODO** and
+              TODO on a wrapped line.
+
+            """
+        When I run vale "test.md"
+        Then the output should contain exactly:
+            """
+            test.md:3:21:vale.Annotations:'TODO' left in text
+            test.md:3:38:vale.Annotations:'TODO' left in text
+            test.md:5:15:vale.Annotations:'TODO' left in text
+            test.md:6:3:vale.Annotations:'TODO' left in text
+            """
+        And the exit status should be 0
+
+    Scenario: Locations of text next to markup
+        Given a file named "_vale" with:
+            """
+            StylesPath = ../../styles/
+            MinAlertLevel = suggestion
+
+            [*]
+            vale.Annotations = YES
+            """
+        And a file named "test.md" with:
+            """
+            ---
+            title: TODO
+            ---
+
+            See [TODO](TODO) and \*TODO\* &amp; TODO.
+
+            A <span title="TODO">TODO</span> span.
+
+            """
+        When I run vale "test.md"
+        Then the output should contain exactly:
+            """
+            test.md:5:24:vale.Annotations:'TODO' left in text
+            test.md:5:37:vale.Annotations:'TODO' left in text
+            test.md:7:22:vale.Annotations:'TODO' left in text
+            """
+        And the exit status should be 0
+
+    Scenario: Project-wide consistency
+        Given a file named "_vale" with:
+            """
+            StylesPath = styles
+
+            [*]
+            BasedOnStyles = Project
+            """
+        And a file named "styles/Project/Email.yml" with:
+            """
+            extends: consistency
+            message: "Inconsistent spelling of '%s'."
+            level: error
+            scope: project
+            either:
+              e-mail: email
+            """
+        And a file named "docs/a.md" with:
+            """
+            Send us an e-mail.
+
+            """
+        And a file named "docs/b.md" with:
+            """
+            Send us an email (or an e-mail).
+
+            """
+        When I run vale "docs"
+        Then the output should contain exactly:
+            """
+            docs/b.md:1:12:Project.Email:Inconsistent spelling of 'email'.
+            """
+        And the exit status should be 1
+
+    Scenario: Document structure
+        Given a file named "_vale" with:
+            """
+            StylesPath = styles
+
+            [*]
+            BasedOnStyles = Docs
+            """
+        And a file named "styles/Docs/Outline.yml" with:
+            """
+            extends: structure
+            message: "'%s' %s."
+            level: error
+            increment: true
+            single: true
+            sections:
+              - Prerequisites
+              - Procedure
+            """
+        And a file named "test.md" with:
+            """
+            # Install
+
+            ## Procedure
+
+            #### Steps
+
+            ## Prerequisites
+
+            # Verify
+
+            """
+        When I run vale "test.md"
+        Then the output should contain exactly:
+            """
+            test.md:3:4:Docs.Outline:'Procedure' should come after 'Prerequisites'.
+            test.md:5:6:Docs.Outline:'Steps' skips from h2 to h4.
+            test.md:9:3:Docs.Outline:'Verify' is not the only h1.
+            """
+        And the exit status should be 1
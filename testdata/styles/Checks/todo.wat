@@ -0,0 +1,68 @@
+;; A minimal `plugin` module that reports a block starting with "TODO".
+;;
+;; Build with `wat2wasm todo.wat -o todo.wasm`.
+(module
+  (memory (export "memory") 1)
+  (global $heap (mut i32) (i32.const 1024))
+
+  (data (i32.const 0) "[{\"begin\":0,\"end\":4,\"severity\":\"warning\"}]")
+  (data (i32.const 64) "[]")
+
+  ;; A bump allocator, which `check` resets on every call.
+  (func (export "alloc") (param $size i32) (result i32)
+    (local $ptr i32)
+    global.get $heap
+    local.set $ptr
+    global.get $heap
+    local.get $size
+    i32.add
+    global.set $heap
+    global.get $heap
+    memory.size
+    i32.const 16
+    i32.shl
+    i32.gt_u
+    if
+      global.get $heap
+      memory.size
+      i32.const 16
+      i32.shl
+      i32.sub
+      i32.const 16
+      i32.shr_u
+      i32.const 1
+      i32.add
+      memory.grow
+      drop
+    end
+    local.get $ptr)
+
+  (func (export "check")
+    (param $text i32) (param $len i32) (param $meta i32) (param $mlen i32)
+    (result i64)
+    i32.const 1024
+    global.set $heap
+    local.get $len
+    i32.const 4
+    i32.ge_u
+    if (result i64)
+      local.get $text
+      i32.load align=1
+      i32.const 0x4f444f54 ;; "TODO"
+      i32.eq
+      if (result i64)
+        i64.const 42 ;; the first data segment, at 0
+      else
+        i64.const 0x4000000002 ;; the second data segment, at 64
+      end
+    else
+      i64.const 0x4000000002
+    end)
+
+  ;; Never returns, for testing the `timeout`.
+  (func (export "loop")
+    (param i32) (param i32) (param i32) (param i32)
+    (result i64)
+    (loop $forever
+      br $forever)
+    unreachable))